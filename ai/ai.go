@@ -0,0 +1,190 @@
+package ai
+
+import (
+	"fmt"
+	"math/rand"
+
+	bg "github.com/quibbble/go-boardgame"
+	tsuro "github.com/quibbble/go-tsuro"
+)
+
+// Difficulty levels
+const (
+	LevelRandom    = "Random"    // plays any legal placement
+	LevelGreedy    = "Greedy"    // survives this turn while knocking out as many other tokens as possible
+	LevelLookahead = "Lookahead" // searches its own placements a few turns ahead with the other teams replying
+)
+
+var Levels = []string{LevelRandom, LevelGreedy, LevelLookahead}
+
+const defaultDepth = 3
+
+// Bot picks the next action for a team in a game of Tsuro
+type Bot interface {
+	// Action returns the action team should perform next
+	Action(game *tsuro.Tsuro, team string) (*bg.BoardGameAction, error)
+}
+
+// NewBot creates a bot playing at the given level with seeded randomness used to break ties
+func NewBot(level string, seed int64) (Bot, error) {
+	random := rand.New(rand.NewSource(seed))
+	switch level {
+	case LevelRandom:
+		return &randomBot{random: random}, nil
+	case LevelGreedy:
+		return &greedyBot{random: random}, nil
+	case LevelLookahead:
+		return &lookaheadBot{random: random, depth: defaultDepth}, nil
+	default:
+		return nil, fmt.Errorf("invalid level %s", level)
+	}
+}
+
+// place returns the action placing the tile as in placement
+func place(team string, placement *tsuro.PlacementTarget) *bg.BoardGameAction {
+	return &bg.BoardGameAction{
+		Team:       team,
+		ActionType: tsuro.ActionPlaceTile,
+		MoreDetails: tsuro.PlaceTileActionDetails{
+			Row:    placement.Row,
+			Column: placement.Column,
+			Tile:   placement.Tile,
+		},
+	}
+}
+
+// turn reads the game from team's point of view returning the token placement actions if tokens are still being placed
+// and otherwise the placements the game allows along with the alliances in the Partners variant
+func turn(game *tsuro.Tsuro, team string) ([]*tsuro.PlacementTarget, []*bg.BoardGameAction, [][]string, error) {
+	snapshot, err := game.GetSnapshot(team)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(snapshot.Winners) > 0 {
+		return nil, nil, nil, fmt.Errorf("game already over")
+	}
+	if snapshot.Turn != team {
+		return nil, nil, nil, fmt.Errorf("%s cannot play on %s turn", team, snapshot.Turn)
+	}
+	data, ok := snapshot.MoreData.(tsuro.TsuroSnapshotData)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("unexpected snapshot data")
+	}
+	tokens := make([]*bg.BoardGameAction, 0)
+	if targets, ok := snapshot.Targets.([]*bg.BoardGameAction); ok {
+		for _, target := range targets {
+			if target.ActionType == tsuro.ActionPlaceToken {
+				tokens = append(tokens, target)
+			}
+		}
+	}
+	if len(tokens) > 0 {
+		return nil, tokens, data.Alliances, nil
+	}
+	placements, err := game.Placements(team)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(placements) == 0 {
+		return nil, nil, nil, fmt.Errorf("%s has no legal placements", team)
+	}
+	return placements, nil, data.Alliances, nil
+}
+
+// knockouts counts the eliminated teams that play against team and those allied with team other than team itself
+func knockouts(alliances [][]string, team string, eliminated []string) (int, int) {
+	allies := map[string]bool{team: true}
+	for _, alliance := range alliances {
+		for _, member := range alliance {
+			if member == team {
+				for _, ally := range alliance {
+					allies[ally] = true
+				}
+			}
+		}
+	}
+	opponents, allied := 0, 0
+	for _, t := range eliminated {
+		if !allies[t] {
+			opponents++
+		} else if t != team {
+			allied++
+		}
+	}
+	return opponents, allied
+}
+
+// choose picks between the best scoring placements at random
+func choose(random *rand.Rand, options []*tsuro.PlacementTarget, score func(*tsuro.PlacementTarget) int) *tsuro.PlacementTarget {
+	best := make([]*tsuro.PlacementTarget, 0)
+	bestScore := 0
+	for _, option := range options {
+		s := score(option)
+		if len(best) == 0 || s > bestScore {
+			best = []*tsuro.PlacementTarget{option}
+			bestScore = s
+		} else if s == bestScore {
+			best = append(best, option)
+		}
+	}
+	return best[random.Intn(len(best))]
+}
+
+type randomBot struct {
+	random *rand.Rand
+}
+
+func (b *randomBot) Action(game *tsuro.Tsuro, team string) (*bg.BoardGameAction, error) {
+	placements, tokens, _, err := turn(game, team)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		return tokens[b.random.Intn(len(tokens))], nil
+	}
+	return place(team, placements[b.random.Intn(len(placements))]), nil
+}
+
+type greedyBot struct {
+	random *rand.Rand
+}
+
+func (b *greedyBot) Action(game *tsuro.Tsuro, team string) (*bg.BoardGameAction, error) {
+	placements, tokens, alliances, err := turn(game, team)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		return tokens[b.random.Intn(len(tokens))], nil
+	}
+	return place(team, choose(b.random, placements, func(placement *tsuro.PlacementTarget) int {
+		opponents, allies := knockouts(alliances, team, placement.Eliminated)
+		if placement.SelfEliminating || allies > 0 {
+			return -1
+		}
+		return opponents
+	})), nil
+}
+
+type lookaheadBot struct {
+	random *rand.Rand
+	depth  int
+}
+
+func (b *lookaheadBot) Action(game *tsuro.Tsuro, team string) (*bg.BoardGameAction, error) {
+	placements, tokens, alliances, err := turn(game, team)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) > 0 {
+		return tokens[b.random.Intn(len(tokens))], nil
+	}
+	// search a copy with the tiles team cannot see dealt at random so the bot never uses hidden information
+	sample, err := game.Sample(tsuro.View{Mode: tsuro.ViewPlayer, Team: team}, b.random.Int63())
+	if err != nil {
+		return nil, err
+	}
+	return place(team, choose(b.random, placements, func(placement *tsuro.PlacementTarget) int {
+		return search(sample, alliances, team, placement, b.depth)
+	})), nil
+}
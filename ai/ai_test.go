@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	tsuro "github.com/quibbble/go-tsuro"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BotsFinishGames(t *testing.T) {
+	teams := []string{"TeamA", "TeamB", "TeamC", "TeamD"}
+	variants := []tsuro.TsuroMoreOptions{
+		{Variant: tsuro.VariantClassic},
+		{Variant: tsuro.VariantSeas},
+		{Variant: tsuro.VariantPartners, Alliances: [][]string{{"TeamA", "TeamC"}, {"TeamB", "TeamD"}}},
+	}
+	for _, level := range Levels {
+		for seed := int64(0); seed < 6; seed++ {
+			options := variants[int(seed)%len(variants)]
+			options.Seed = seed
+			options.NoSuicide = seed%2 == 0
+			game, err := tsuro.NewTsuro(&bg.BoardGameOptions{Teams: teams, MoreOptions: options})
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			bots := make(map[string]Bot)
+			for _, team := range teams {
+				bots[team], err = NewBot(level, seed)
+				if err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+			}
+			for i := 0; i < 100; i++ {
+				snapshot, err := game.GetSnapshot()
+				if err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+				if len(snapshot.Winners) > 0 {
+					break
+				}
+				action, err := bots[snapshot.Turn].Action(game, snapshot.Turn)
+				if err != nil {
+					t.Error(level, options.Variant, seed, err)
+					t.FailNow()
+				}
+				// the placement the bot chose knocks out the teams the game predicted
+				var predicted *tsuro.PlacementTarget
+				if details, ok := action.MoreDetails.(tsuro.PlaceTileActionDetails); ok {
+					placements, _ := game.Placements(snapshot.Turn)
+					for _, placement := range placements {
+						if placement.Tile == details.Tile {
+							predicted = placement
+						}
+					}
+					if !assert.NotNil(t, predicted, level, options.Variant, seed) {
+						t.FailNow()
+					}
+				}
+				if err := game.Do(action); err != nil {
+					t.Error(level, options.Variant, seed, err)
+					t.FailNow()
+				}
+				if predicted != nil {
+					before := snapshot.MoreData.(tsuro.TsuroSnapshotData).Alive
+					after, _ := game.GetSnapshot()
+					alive := after.MoreData.(tsuro.TsuroSnapshotData).Alive
+					for _, team := range before {
+						if options.Variant != tsuro.VariantSeas || contains(alive, team) {
+							// daikaiju moving after the placement can knock out more teams than predicted
+							assert.Equal(t, !contains(predicted.Eliminated, team), contains(alive, team), level, options.Variant, seed, team)
+						}
+					}
+				}
+			}
+			snapshot, _ := game.GetSnapshot()
+			assert.NotEmpty(t, snapshot.Winners, level, options.Variant, seed)
+		}
+	}
+}
+
+func Test_GreedyAvoidsEdge(t *testing.T) {
+	game, err := tsuro.NewTsuro(&bg.BoardGameOptions{
+		Teams:       []string{"TeamA", "TeamB"},
+		MoreOptions: tsuro.TsuroMoreOptions{Seed: 123},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	_ = game.Do(&bg.BoardGameAction{Team: "TeamA", ActionType: tsuro.ActionPlaceToken, MoreDetails: tsuro.PlaceTokenActionDetails{Row: 0, Column: 0, Notch: "A"}})
+	_ = game.Do(&bg.BoardGameAction{Team: "TeamB", ActionType: tsuro.ActionPlaceToken, MoreDetails: tsuro.PlaceTokenActionDetails{Row: 5, Column: 5, Notch: "E"}})
+
+	bot, _ := NewBot(LevelGreedy, 0)
+	action, err := bot.Action(game, "TeamA")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if err := game.Do(action); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	snapshot, _ := game.GetSnapshot()
+	assert.Contains(t, snapshot.MoreData.(tsuro.TsuroSnapshotData).Alive, "TeamA")
+}
+
+func contains(list []string, item string) bool {
+	for _, val := range list {
+		if val == item {
+			return true
+		}
+	}
+	return false
+}
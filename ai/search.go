@@ -0,0 +1,84 @@
+package ai
+
+import (
+	tsuro "github.com/quibbble/go-tsuro"
+)
+
+// follower tracks a copy of a game being searched through its observer callbacks so each move does not need a snapshot
+type follower struct {
+	tsuro.BaseObserver
+	team  string
+	turn  string
+	alive bool
+	over  bool
+}
+
+func (f *follower) OnPlayerEliminated(team, reason string) {
+	if team == f.team {
+		f.alive = false
+	}
+}
+
+func (f *follower) OnTurnChanged(team string) {
+	f.turn = team
+}
+
+func (f *follower) OnGameOver(winners []string) {
+	f.over = true
+}
+
+// search scores team's placement by playing it on a copy of game, letting the other teams reply with placements
+// that keep them on the board, and adding the best score of team's follow up placements until depth runs out
+func search(game *tsuro.Tsuro, alliances [][]string, team string, placement *tsuro.PlacementTarget, depth int) int {
+	if placement.SelfEliminating {
+		return -1000 * depth
+	}
+	opponents, allies := knockouts(alliances, team, placement.Eliminated)
+	score := 100*depth + 10*opponents - 50*allies
+	if depth <= 1 {
+		return score
+	}
+	next := game.Clone()
+	f := &follower{team: team, turn: team, alive: true}
+	next.AddObserver(f)
+	if err := next.Do(place(team, placement)); err != nil {
+		return score
+	}
+	for f.alive && !f.over && f.turn != team {
+		reply := survive(next, f.turn)
+		if reply == nil || next.Do(place(f.turn, reply)) != nil {
+			return score
+		}
+	}
+	if !f.alive {
+		return score - 1000*(depth-1)
+	}
+	if f.over {
+		return score
+	}
+	placements, err := next.Placements(team)
+	if err != nil || len(placements) == 0 {
+		return score
+	}
+	best := 0
+	for idx, p := range placements {
+		if s := search(next, alliances, team, p, depth-1); idx == 0 || s > best {
+			best = s
+		}
+	}
+	return score + best
+}
+
+// survive returns the first placement that keeps team on the board or any placement if none do
+func survive(game *tsuro.Tsuro, team string) *tsuro.PlacementTarget {
+	placements, err := game.Placements(team)
+	if err != nil || len(placements) == 0 {
+		return nil
+	}
+	for _, placement := range placements {
+		if !placement.SelfEliminating {
+			return placement
+		}
+	}
+	return placements[0]
+}
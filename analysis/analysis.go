@@ -0,0 +1,136 @@
+package analysis
+
+import (
+	"fmt"
+	"math/rand"
+
+	bg "github.com/quibbble/go-boardgame"
+	tsuro "github.com/quibbble/go-tsuro"
+)
+
+const defaultPlayouts = 1000
+
+// Options configure how a position is estimated
+type Options struct {
+	Playouts int        // random games played to the end from the position and after each placement which defaults to 1000
+	Seed     int64      // seed for dealing hidden tiles and choosing random placements
+	View     tsuro.View // what is known about the hands which defaults to the spectator view
+}
+
+// Estimate is the outcome of the playouts from a position
+type Estimate struct {
+	Playouts   int
+	Survival   map[string]float64 // share of playouts each team is still on the board at the end
+	Win        map[string]float64 // share of playouts each team is one of the winners including ties
+	Placements []*Placement       // legal placements of the team on turn when the view sees their hand
+}
+
+// Placement is the expected outcome for the placing team of one of its legal placements
+type Placement struct {
+	Row, Column int
+	Tile        string
+	Survival    float64 // share of playouts after the placement the placing team is still on the board at the end
+	Win         float64 // share of playouts after the placement the placing team is one of the winners
+}
+
+// Analyze plays random games to the end from the game's position dealing the tiles the view cannot see at random in each
+func Analyze(game *tsuro.Tsuro, options Options) (*Estimate, error) {
+	if options.Playouts < 0 {
+		return nil, fmt.Errorf("playouts cannot be negative")
+	} else if options.Playouts == 0 {
+		options.Playouts = defaultPlayouts
+	}
+	if options.View.Mode == "" {
+		options.View.Mode = tsuro.ViewSpectator
+	}
+	snapshot, err := game.GetView(options.View)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot.Winners) > 0 {
+		return nil, fmt.Errorf("game already over")
+	}
+	random := rand.New(rand.NewSource(options.Seed))
+	survived, won, err := playouts(game, options, random, nil)
+	if err != nil {
+		return nil, err
+	}
+	estimate := &Estimate{
+		Playouts: options.Playouts,
+		Survival: share(survived, snapshot.Teams, options.Playouts),
+		Win:      share(won, snapshot.Teams, options.Playouts),
+	}
+	data := snapshot.MoreData.(tsuro.TsuroSnapshotData)
+	if _, ok := data.Hands[snapshot.Turn]; !ok {
+		return estimate, nil
+	}
+	placements, err := game.Placements(snapshot.Turn)
+	if err != nil {
+		return nil, err
+	}
+	estimate.Placements = make([]*Placement, 0, len(placements))
+	for _, placement := range placements {
+		action := &bg.BoardGameAction{
+			Team:       snapshot.Turn,
+			ActionType: tsuro.ActionPlaceTile,
+			MoreDetails: tsuro.PlaceTileActionDetails{
+				Row:    placement.Row,
+				Column: placement.Column,
+				Tile:   placement.Tile,
+			},
+		}
+		survived, won, err := playouts(game, options, random, action)
+		if err != nil {
+			return nil, err
+		}
+		estimate.Placements = append(estimate.Placements, &Placement{
+			Row:      placement.Row,
+			Column:   placement.Column,
+			Tile:     placement.Tile,
+			Survival: float64(survived[snapshot.Turn]) / float64(options.Playouts),
+			Win:      float64(won[snapshot.Turn]) / float64(options.Playouts),
+		})
+	}
+	return estimate, nil
+}
+
+// playouts counts how often each team survives and wins the playouts made after first if it is not nil
+func playouts(game *tsuro.Tsuro, options Options, random *rand.Rand, first *bg.BoardGameAction) (map[string]int, map[string]int, error) {
+	survived := make(map[string]int)
+	won := make(map[string]int)
+	for i := 0; i < options.Playouts; i++ {
+		sample, err := game.Sample(options.View, random.Int63())
+		if err != nil {
+			return nil, nil, err
+		}
+		p, err := newPlayout(sample)
+		if err != nil {
+			return nil, nil, err
+		}
+		if first != nil {
+			if err := sample.Do(first); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err := p.play(random); err != nil {
+			return nil, nil, err
+		}
+		for team, alive := range p.alive {
+			if alive {
+				survived[team]++
+			}
+		}
+		for _, team := range p.winners {
+			won[team]++
+		}
+	}
+	return survived, won, nil
+}
+
+func share(counts map[string]int, teams []string, playouts int) map[string]float64 {
+	result := make(map[string]float64)
+	for _, team := range teams {
+		result[team] = float64(counts[team]) / float64(playouts)
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"math/rand"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	tsuro "github.com/quibbble/go-tsuro"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Analyze(t *testing.T) {
+	teams := []string{"TeamA", "TeamB", "TeamC"}
+	game, err := tsuro.NewTsuro(&bg.BoardGameOptions{
+		Teams:       teams,
+		MoreOptions: tsuro.TsuroMoreOptions{Seed: 4, RandomTokens: true},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	random := rand.New(rand.NewSource(4))
+	for i := 0; i < 4; i++ {
+		snapshot, _ := game.GetSnapshot()
+		placements, _ := game.Placements(snapshot.Turn)
+		placement := placements[random.Intn(len(placements))]
+		assert.NoError(t, game.Do(&bg.BoardGameAction{
+			Team:        snapshot.Turn,
+			ActionType:  tsuro.ActionPlaceTile,
+			MoreDetails: tsuro.PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+		}))
+	}
+	snapshot, _ := game.GetSnapshot()
+	if len(snapshot.Winners) > 0 {
+		t.Fatal("game should not be over")
+	}
+	alive := snapshot.MoreData.(tsuro.TsuroSnapshotData).Alive
+
+	tests := []struct {
+		name       string
+		options    Options
+		placements bool
+		err        bool
+	}{
+		{name: "spectator", options: Options{Playouts: 100, Seed: 1}},
+		{name: "turn player", options: Options{Playouts: 100, Seed: 1, View: tsuro.View{Mode: tsuro.ViewPlayer, Team: snapshot.Turn}}, placements: true},
+		{name: "omniscient", options: Options{Playouts: 100, Seed: 1, View: tsuro.View{Mode: tsuro.ViewOmniscient}}, placements: true},
+		{name: "negative playouts", options: Options{Playouts: -1}, err: true},
+		{name: "replay view", options: Options{Playouts: 1, View: tsuro.View{Mode: tsuro.ViewReplay}}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimate, err := Analyze(game, test.options)
+			assert.Equal(t, test.err, err != nil)
+			if test.err {
+				return
+			}
+			total := 0.0
+			for _, team := range teams {
+				assert.GreaterOrEqual(t, estimate.Win[team], 0.0)
+				assert.LessOrEqual(t, estimate.Survival[team], 1.0)
+				if !contains(alive, team) {
+					assert.Zero(t, estimate.Survival[team])
+					assert.Zero(t, estimate.Win[team])
+				}
+				total += estimate.Win[team]
+			}
+			// every playout has at least one winner
+			assert.GreaterOrEqual(t, total, 1.0)
+			assert.Equal(t, test.placements, len(estimate.Placements) > 0)
+
+			placements, _ := game.Placements(snapshot.Turn)
+			for idx, placement := range estimate.Placements {
+				assert.Equal(t, placements[idx].Tile, placement.Tile)
+				if placements[idx].SelfEliminating {
+					assert.Zero(t, placement.Survival)
+				}
+			}
+
+			// the same seed gives the same estimate
+			again, _ := Analyze(game, test.options)
+			assert.Equal(t, estimate, again)
+		})
+	}
+
+	// the game itself is left as it was
+	after, _ := game.GetSnapshot()
+	assert.Equal(t, snapshot, after)
+}
+
+func contains(list []string, item string) bool {
+	for _, val := range list {
+		if val == item {
+			return true
+		}
+	}
+	return false
+}
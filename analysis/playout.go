@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"fmt"
+	"math/rand"
+
+	bg "github.com/quibbble/go-boardgame"
+	tsuro "github.com/quibbble/go-tsuro"
+)
+
+// maxActions bounds a playout as every game ends once the board or the tiles run out
+const maxActions = 1000
+
+// playout follows a sampled game through its observer callbacks so each move does not need a snapshot
+type playout struct {
+	tsuro.BaseObserver
+	game    *tsuro.Tsuro
+	turn    string
+	alive   map[string]bool
+	winners []string
+}
+
+func newPlayout(game *tsuro.Tsuro) (*playout, error) {
+	snapshot, err := game.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	p := &playout{
+		game:    game,
+		turn:    snapshot.Turn,
+		alive:   make(map[string]bool),
+		winners: snapshot.Winners,
+	}
+	for _, team := range snapshot.MoreData.(tsuro.TsuroSnapshotData).Alive {
+		p.alive[team] = true
+	}
+	game.AddObserver(p)
+	return p, nil
+}
+
+func (p *playout) OnPlayerEliminated(team, reason string) {
+	p.alive[team] = false
+}
+
+func (p *playout) OnTurnChanged(team string) {
+	p.turn = team
+}
+
+func (p *playout) OnGameOver(winners []string) {
+	p.winners = winners
+}
+
+// play makes random legal moves until the game is over
+func (p *playout) play(random *rand.Rand) error {
+	for i := 0; i < maxActions && len(p.winners) == 0; i++ {
+		action, err := p.move(random)
+		if err != nil {
+			return err
+		}
+		if err := p.game.Do(action); err != nil {
+			return err
+		}
+	}
+	if len(p.winners) == 0 {
+		return fmt.Errorf("playout did not finish within %d actions", maxActions)
+	}
+	return nil
+}
+
+// move picks a random legal placement for the team on turn or a random starting notch while tokens are being placed
+func (p *playout) move(random *rand.Rand) (*bg.BoardGameAction, error) {
+	placements, err := p.game.Placements(p.turn)
+	if err != nil {
+		return nil, err
+	}
+	if len(placements) > 0 {
+		placement := placements[random.Intn(len(placements))]
+		return &bg.BoardGameAction{
+			Team:       p.turn,
+			ActionType: tsuro.ActionPlaceTile,
+			MoreDetails: tsuro.PlaceTileActionDetails{
+				Row:    placement.Row,
+				Column: placement.Column,
+				Tile:   placement.Tile,
+			},
+		}, nil
+	}
+	snapshot, err := p.game.GetSnapshot(p.turn)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*bg.BoardGameAction, 0)
+	if targets, ok := snapshot.Targets.([]*bg.BoardGameAction); ok {
+		for _, target := range targets {
+			if target.ActionType == tsuro.ActionPlaceToken {
+				tokens = append(tokens, target)
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%s has no legal moves", p.turn)
+	}
+	return tokens[random.Intn(len(tokens))], nil
+}
@@ -3,16 +3,85 @@ package go_tsuro
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/mitchellh/mapstructure"
 	bg "github.com/quibbble/go-boardgame"
 	"github.com/quibbble/go-boardgame/pkg/bgerr"
+	"github.com/quibbble/go-boardgame/pkg/bgn"
 )
 
 var (
-	actionToNotation = map[string]string{ActionPlaceTile: "p", bg.ActionSetWinners: "w"}
+	actionToNotation = map[string]string{
+		ActionPlaceToken:      "t",
+		ActionRotateTileRight: "r",
+		ActionRotateTileLeft:  "l",
+		ActionPlaceTile:       "p",
+		ActionMoveDaikaiju:    "m",
+		ActionTimeout:         "o",
+		ActionResign:          "q",
+		bg.ActionSetWinners:   "w",
+	}
 	notationToAction = reverseMap(actionToNotation)
 )
 
+func (r *RotateTileActionDetails) encodeBGN() []string {
+	if r.Index != nil {
+		return []string{r.Tile, strconv.Itoa(*r.Index)}
+	}
+	return []string{r.Tile}
+}
+
+func decodeRotateTileActionDetailsBGN(notation []string) (*RotateTileActionDetails, error) {
+	var index *int
+	if last := len(notation) - 1; last > 0 {
+		// an index picking out one of several copies of the tile follows the tile
+		if idx, err := strconv.Atoi(notation[last]); err == nil {
+			index = &idx
+			notation = notation[:last]
+		}
+	}
+	if len(notation) == 2 {
+		// readable notation such as #17.R2
+		tile, err := ParseTile(strings.Join(notation, " "))
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+		return &RotateTileActionDetails{Tile: tile, Index: index}, nil
+	}
+	if len(notation) != 1 {
+		return nil, loadFailure(fmt.Errorf("invalid rotate tile notation"))
+	}
+	return &RotateTileActionDetails{
+		Tile:  notation[0],
+		Index: index,
+	}, nil
+}
+
+func (p *PlaceTokenActionDetails) encodeBGN() []string {
+	return []string{strconv.Itoa(p.Row), strconv.Itoa(p.Column), p.Notch}
+}
+
+func decodePlaceTokenActionDetailsBGN(notation []string) (*PlaceTokenActionDetails, error) {
+	if len(notation) != 3 {
+		return nil, loadFailure(fmt.Errorf("invalid place token notation"))
+	}
+	row, err := strconv.Atoi(notation[0])
+	if err != nil {
+		return nil, loadFailure(err)
+	}
+	column, err := strconv.Atoi(notation[1])
+	if err != nil {
+		return nil, loadFailure(err)
+	}
+	notch := notation[2]
+	return &PlaceTokenActionDetails{
+		Row:    row,
+		Column: column,
+		Notch:  notch,
+	}, nil
+}
+
 func (p *PlaceTileActionDetails) encodeBGN() []string {
 	return []string{strconv.Itoa(p.Row), strconv.Itoa(p.Column), p.Tile}
 }
@@ -21,6 +90,14 @@ func decodePlaceTileActionDetailsBGN(notation []string) (*PlaceTileActionDetails
 	if len(notation) != 3 {
 		return nil, loadFailure(fmt.Errorf("invalid place tile notation"))
 	}
+	if strings.HasPrefix(notation[1], "#") {
+		// readable notation such as B3.#17.R2
+		placement, err := ParsePlacement(strings.Join(notation, " "))
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+		return &placement, nil
+	}
 	row, err := strconv.Atoi(notation[0])
 	if err != nil {
 		return nil, loadFailure(err)
@@ -37,6 +114,141 @@ func decodePlaceTileActionDetailsBGN(notation []string) (*PlaceTileActionDetails
 	}, nil
 }
 
+func (m *MoveDaikaijuActionDetails) encodeBGN() []string {
+	notation := []string{strconv.Itoa(m.Roll)}
+	for _, direction := range m.Directions {
+		notation = append(notation, strconv.Itoa(direction))
+	}
+	return notation
+}
+
+func decodeMoveDaikaijuActionDetailsBGN(notation []string) (*MoveDaikaijuActionDetails, error) {
+	if len(notation) < 1 {
+		return nil, loadFailure(fmt.Errorf("invalid move daikaiju notation"))
+	}
+	roll, err := strconv.Atoi(notation[0])
+	if err != nil {
+		return nil, loadFailure(err)
+	}
+	directions := make([]int, 0)
+	for _, n := range notation[1:] {
+		direction, err := strconv.Atoi(n)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+		directions = append(directions, direction)
+	}
+	return &MoveDaikaijuActionDetails{
+		Roll:       roll,
+		Directions: directions,
+	}, nil
+}
+
+func (t *TimeoutActionDetails) encodeBGN() []string {
+	return []string{t.Move}
+}
+
+func decodeTimeoutActionDetailsBGN(notation []string) (*TimeoutActionDetails, error) {
+	if len(notation) != 1 || !contains(timeoutMoves, notation[0]) {
+		return nil, loadFailure(fmt.Errorf("invalid timeout notation"))
+	}
+	return &TimeoutActionDetails{Move: notation[0]}, nil
+}
+
+// encodeActionBGN writes action in notation
+func encodeActionBGN(teams []string, action *bg.BoardGameAction) bgn.Action {
+	bgnAction := bgn.Action{
+		TeamIndex: indexOf(teams, action.Team),
+		ActionKey: rune(actionToNotation[action.ActionType][0]),
+	}
+	switch action.ActionType {
+	case ActionPlaceToken:
+		var details PlaceTokenActionDetails
+		_ = mapstructure.Decode(action.MoreDetails, &details)
+		bgnAction.Details = details.encodeBGN()
+	case ActionRotateTileRight, ActionRotateTileLeft:
+		var details RotateTileActionDetails
+		_ = mapstructure.Decode(action.MoreDetails, &details)
+		bgnAction.Details = details.encodeBGN()
+	case ActionPlaceTile:
+		var details PlaceTileActionDetails
+		_ = mapstructure.Decode(action.MoreDetails, &details)
+		bgnAction.Details = details.encodeBGN()
+	case ActionMoveDaikaiju:
+		var details MoveDaikaijuActionDetails
+		_ = mapstructure.Decode(action.MoreDetails, &details)
+		bgnAction.Details = details.encodeBGN()
+	case ActionTimeout:
+		var details TimeoutActionDetails
+		_ = mapstructure.Decode(action.MoreDetails, &details)
+		bgnAction.Details = details.encodeBGN()
+	case bg.ActionSetWinners:
+		var details bg.SetWinnersActionDetails
+		_ = mapstructure.Decode(action.MoreDetails, &details)
+		bgnAction.Details, _ = details.EncodeBGN(teams)
+	}
+	return bgnAction
+}
+
+// decodeActionBGN converts an action in notation back into the action it records
+func decodeActionBGN(teams []string, action bgn.Action) (*bg.BoardGameAction, error) {
+	if action.TeamIndex < 0 || action.TeamIndex >= len(teams) {
+		return nil, loadFailure(fmt.Errorf("team index %d out of range", action.TeamIndex))
+	}
+	actionType := notationToAction[string(action.ActionKey)]
+	if actionType == "" {
+		return nil, loadFailure(fmt.Errorf("invalid action key %s", string(action.ActionKey)))
+	}
+	var details interface{}
+	switch actionType {
+	case ActionPlaceToken:
+		result, err := decodePlaceTokenActionDetailsBGN(action.Details)
+		if err != nil {
+			return nil, err
+		}
+		details = *result
+	case ActionRotateTileRight, ActionRotateTileLeft:
+		result, err := decodeRotateTileActionDetailsBGN(action.Details)
+		if err != nil {
+			return nil, err
+		}
+		details = *result
+	case ActionPlaceTile:
+		result, err := decodePlaceTileActionDetailsBGN(action.Details)
+		if err != nil {
+			return nil, err
+		}
+		details = *result
+	case ActionMoveDaikaiju:
+		result, err := decodeMoveDaikaijuActionDetailsBGN(action.Details)
+		if err != nil {
+			return nil, err
+		}
+		details = *result
+	case ActionTimeout:
+		result, err := decodeTimeoutActionDetailsBGN(action.Details)
+		if err != nil {
+			return nil, err
+		}
+		details = *result
+	case ActionResign:
+		if len(action.Details) != 0 {
+			return nil, loadFailure(fmt.Errorf("invalid resign notation"))
+		}
+	case bg.ActionSetWinners:
+		result, err := bg.DecodeSetWinnersActionDetailsBGN(action.Details, teams)
+		if err != nil {
+			return nil, err
+		}
+		details = *result
+	}
+	return &bg.BoardGameAction{
+		Team:        teams[action.TeamIndex],
+		ActionType:  actionType,
+		MoreDetails: details,
+	}, nil
+}
+
 func loadFailure(err error) error {
 	return &bgerr.Error{
 		Err:    err,
@@ -3,24 +3,26 @@ package go_tsuro
 import "fmt"
 
 const (
-	rows    = 6
-	columns = 6
+	defaultBoardSize = 6
+	minBoardSize     = 4
+	maxBoardSize     = 12
 )
 
 type board struct {
-	board [][]*tile // 0,0 is top left corner
+	board         [][]*tile // 0,0 is top left corner
+	rows, columns int
 }
 
-func newBoard() *board {
+func newBoard(rows, columns int) *board {
 	var b = make([][]*tile, rows)
 	for i := 0; i < rows; i++ {
 		b[i] = make([]*tile, columns)
 	}
-	return &board{board: b}
+	return &board{board: b, rows: rows, columns: columns}
 }
 
 func (b *board) Place(tile *tile, row, col int) error {
-	if row < 0 || col < 0 || row >= rows || col >= columns {
+	if row < 0 || col < 0 || row >= b.rows || col >= b.columns {
 		return fmt.Errorf("index out of bounds")
 	}
 	if b.board[row][col] != nil {
@@ -30,6 +32,29 @@ func (b *board) Place(tile *tile, row, col int) error {
 	return nil
 }
 
+func (b *board) copy() *board {
+	copied := newBoard(b.rows, b.columns)
+	for row := range b.board {
+		for col, tile := range b.board[row] {
+			if tile != nil {
+				copied.board[row][col] = tile.copy()
+			}
+		}
+	}
+	return copied
+}
+
+// share copies the squares of the board without copying the tiles on them
+func (b *board) share() *board {
+	squares := make([]*tile, b.rows*b.columns)
+	shared := make([][]*tile, b.rows)
+	for row := range b.board {
+		shared[row] = squares[row*b.columns : (row+1)*b.columns]
+		copy(shared[row], b.board[row])
+	}
+	return &board{board: shared, rows: b.rows, columns: b.columns}
+}
+
 func (b *board) getTileCount() int {
 	counter := 0
 	for _, row := range b.board {
@@ -41,3 +66,8 @@ func (b *board) getTileCount() int {
 	}
 	return counter
 }
+
+// isFull is true when no more tiles can be placed as the last open square can only be moved into
+func (b *board) isFull() bool {
+	return b.getTileCount() >= b.rows*b.columns-1
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/mitchellh/mapstructure"
 	bg "github.com/quibbble/go-boardgame"
 	"github.com/quibbble/go-boardgame/pkg/bgn"
 )
@@ -34,66 +36,211 @@ func (b *Builder) Load(game *bgn.Game) (bg.BoardGameWithBGN, error) {
 	if !(variantStr == "" || contains(variants, variantStr)) {
 		return nil, loadFailure(fmt.Errorf("invalid variant value"))
 	}
+	var err error
+	secretSeed := false
+	if secretSeedStr, ok := game.Tags["SecretSeed"]; ok {
+		secretSeed, err = strconv.ParseBool(secretSeedStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+	}
+	seed := 0
 	seedStr, ok := game.Tags["Seed"]
-	if !ok {
+	if !ok && !secretSeed {
 		return nil, loadFailure(fmt.Errorf("missing seed tag"))
+	} else if ok {
+		seed, err = strconv.Atoi(seedStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+	}
+	// tokens were always placed at random in games recorded before the tag was added
+	randomTokens := true
+	if randomTokensStr, ok := game.Tags["RandomTokens"]; ok {
+		randomTokens, err = strconv.ParseBool(randomTokensStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+	}
+	collapseRotations := false
+	if collapseRotationsStr, ok := game.Tags["CollapseRotations"]; ok {
+		collapseRotations, err = strconv.ParseBool(collapseRotationsStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+	}
+	noSuicide := false
+	if noSuicideStr, ok := game.Tags["NoSuicide"]; ok {
+		noSuicide, err = strconv.ParseBool(noSuicideStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+	}
+	var alliances [][]string
+	if alliancesStr, ok := game.Tags["Alliances"]; ok {
+		for _, alliance := range strings.Split(alliancesStr, "; ") {
+			alliances = append(alliances, strings.Split(alliance, ", "))
+		}
 	}
-	seed, err := strconv.Atoi(seedStr)
-	if err != nil {
-		return nil, loadFailure(err)
+	var turnTime, gameTime time.Duration
+	if turnTimeStr, ok := game.Tags["TurnTime"]; ok {
+		turnTime, err = time.ParseDuration(turnTimeStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+	}
+	if gameTimeStr, ok := game.Tags["GameTime"]; ok {
+		gameTime, err = time.ParseDuration(gameTimeStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+	}
+	timeoutMove := game.Tags["TimeoutMove"]
+	if !(timeoutMove == "" || contains(timeoutMoves, timeoutMove)) {
+		return nil, loadFailure(fmt.Errorf("invalid timeout move value"))
+	}
+	var tileSet []string
+	if tilesStr, ok := game.Tags["Tiles"]; ok {
+		tileSet = strings.Split(tilesStr, ", ")
+	}
+	boardSize := 0
+	if boardSizeStr, ok := game.Tags["BoardSize"]; ok {
+		boardSize, err = strconv.Atoi(boardSizeStr)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
 	}
-	g, err := b.CreateWithBGN(&bg.BoardGameOptions{
+	options := &bg.BoardGameOptions{
 		Teams: teams,
 		MoreOptions: TsuroMoreOptions{
-			Seed:    int64(seed),
-			Variant: variantStr,
+			Seed:              int64(seed),
+			Variant:           variantStr,
+			RandomTokens:      randomTokens,
+			CollapseRotations: collapseRotations,
+			BoardSize:         boardSize,
+			NoSuicide:         noSuicide,
+			Alliances:         alliances,
+			TurnTime:          turnTime,
+			GameTime:          gameTime,
+			TimeoutMove:       timeoutMove,
+			SecretSeed:        secretSeed,
+			Tiles:             tileSet,
 		},
-	})
-	if err != nil {
-		return nil, err
 	}
+	actions := make([]*bg.BoardGameAction, 0)
 	for _, action := range game.Actions {
-		if action.TeamIndex >= len(teams) {
-			return nil, loadFailure(fmt.Errorf("team index %d out of range", action.TeamIndex))
-		}
-		team := teams[action.TeamIndex]
-		actionType := notationToAction[string(action.ActionKey)]
-		if actionType == "" {
-			return nil, loadFailure(fmt.Errorf("invalid action key %s", string(action.ActionKey)))
-		}
-		var details interface{}
-		switch actionType {
-		case ActionPlaceTile:
-			result, err := decodePlaceTileActionDetailsBGN(action.Details)
-			if err != nil {
-				return nil, err
+		result, err := decodeActionBGN(teams, action)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, result)
+	}
+	var draws []string
+	if drawsStr, ok := game.Tags["Draws"]; ok && drawsStr != "" {
+		draws = strings.Split(drawsStr, ", ")
+	}
+	var g *Tsuro
+	if secretSeed && seedStr == "" {
+		// without the seed the recorded draws, tokens, and daikaiju are replayed in its place
+		// a game in progress only records the draws of placed tiles so the hands it deals in place of hidden draws are not the real ones
+		if _, ok := game.Tags["Draws"]; !ok {
+			return nil, loadFailure(fmt.Errorf("missing draws tag in place of the secret seed"))
+		}
+		script, err := loadScript(game.Tags, teams, boardSize, variantStr, randomTokens)
+		if err != nil {
+			return nil, err
+		}
+		script.draws = draws
+		script.placed = placedTiles(actions)
+		script.moves = recordedMoves(actions)
+		g, err = newTsuro(options, script)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		g, err = NewTsuro(options)
+		if err != nil {
+			return nil, err
+		}
+		if hash, ok := game.Tags["SeedHash"]; ok {
+			g.nonce = game.Tags["SeedNonce"]
+			if hash != commit(int64(seed), g.nonce) {
+				return nil, loadFailure(fmt.Errorf("seed does not match the seed hash"))
 			}
-			details = result
-		case bg.ActionSetWinners:
-			result, err := bg.DecodeSetWinnersActionDetailsBGN(action.Details, teams)
-			if err != nil {
+		}
+	}
+	for _, result := range actions {
+		if result.ActionType == ActionTimeout {
+			// timeouts are not actions a team can do so are replayed directly
+			if err := g.replayTimeout(result); err != nil {
 				return nil, err
 			}
-			details = result
+			continue
+		}
+		if result.ActionType == ActionMoveDaikaiju {
+			// daikaiju move on their own after the previous placement so only check the recorded rolls match
+			var recorded MoveDaikaijuActionDetails
+			if len(g.actions) > 0 && g.actions[len(g.actions)-1].ActionType == ActionMoveDaikaiju {
+				_ = mapstructure.Decode(g.actions[len(g.actions)-1].MoreDetails, &recorded)
+			}
+			details := result.MoreDetails.(MoveDaikaijuActionDetails)
+			if !recorded.equals(&details) {
+				return nil, loadFailure(fmt.Errorf("daikaiju move %v does not match the game", result.MoreDetails))
+			}
+			continue
 		}
-		if err := g.Do(&bg.BoardGameAction{
-			Team:        team,
-			ActionType:  actionType,
-			MoreDetails: details,
-		}); err != nil {
+		if err := g.Do(result); err != nil {
 			return nil, err
 		}
 	}
+	if _, ok := game.Tags["Draws"]; ok && !matchesDraws(g.state.deck.drawn, draws) {
+		return nil, loadFailure(fmt.Errorf("drawn tiles do not match the game"))
+	}
+	// the BGN does not record when actions were taken
+	for i := range g.timer.readings {
+		g.timer.readings[i] = nil
+	}
 	return g, nil
 }
 
+// loadScript reads the starting tokens and daikaiju recorded in place of a secret seed
+func loadScript(tags map[string]string, teams []string, boardSize int, variant string, randomTokens bool) (*script, error) {
+	hash, ok := tags["SeedHash"]
+	if !ok {
+		return nil, loadFailure(fmt.Errorf("missing seed hash tag"))
+	}
+	result := &script{Commitment: hash}
+	if boardSize == 0 && variant == VariantSeas {
+		boardSize = seasBoardSize
+	} else if boardSize == 0 {
+		boardSize = defaultBoardSize
+	}
+	if randomTokens {
+		tokens, err := decodeTokensTag(teams, tags["Tokens"], boardSize)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+		result.Tokens = tokens
+	}
+	if variant == VariantSeas {
+		daikaiju, err := decodeDaikaijuTag(tags["Daikaiju"], boardSize)
+		if err != nil {
+			return nil, loadFailure(err)
+		}
+		result.Daikaiju = daikaiju
+	}
+	return result, nil
+}
+
 func (b *Builder) Info() *bg.BoardGameInfo {
 	return &bg.BoardGameInfo{
 		GameKey:  b.Key(),
 		MinTeams: minTeams,
 		MaxTeams: maxTeams,
 		MoreInfo: &TsuroMoreInfo{
-			Variants: variants,
+			Variants:     variants,
+			MinBoardSize: minBoardSize,
+			MaxBoardSize: maxBoardSize,
 		},
 	}
 }
@@ -0,0 +1,62 @@
+package go_tsuro
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CatalogueTile is one of the tiles in the game
+type CatalogueTile struct {
+	ID        int    // stable number from 1 to 35 used by the readable notation
+	Edges     string // canonical orientation from which rotations are counted
+	Rotations int    // number of distinct orientations which is 1, 2, or 4 depending on the tile's symmetry
+}
+
+var catalogue = newCatalogue()
+
+func newCatalogue() []CatalogueTile {
+	result := make([]CatalogueTile, 0, len(shapes))
+	for idx := range shapes {
+		seen := make(map[[notchCount]notch]bool)
+		for _, shape := range shapes[idx] {
+			seen[shape.ends] = true
+		}
+		result = append(result, CatalogueTile{
+			ID:        idx + 1,
+			Edges:     shapes[idx][0].edges,
+			Rotations: len(seen),
+		})
+	}
+	return result
+}
+
+// Catalogue returns every tile in the game in ID order
+func Catalogue() []CatalogueTile {
+	return append([]CatalogueTile{}, catalogue...)
+}
+
+// TileEdges returns the edges of the tile with id rotated right rotation times from its canonical orientation
+func TileEdges(id, rotation int) (string, error) {
+	if id < 1 || id > len(catalogue) {
+		return "", fmt.Errorf("tile id %d must be between 1 and %d", id, len(catalogue))
+	}
+	if rotation < 0 || rotation > 3 {
+		return "", fmt.Errorf("rotation %d must be between 0 and 3", rotation)
+	}
+	return shapes[id-1][rotation].edges, nil
+}
+
+// TileID returns the id of the tile with edges and the fewest right rotations from its canonical orientation giving the same paths
+// so each orientation has a single id and rotation below the tile's number of distinct rotations
+func TileID(edges string) (int, int, error) {
+	letters := []byte(edges)
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	if string(letters) != "ABCDEFGH" {
+		return 0, 0, fmt.Errorf("edges %s are not a valid tile configuration", edges)
+	}
+	shape, ok := shapesByEnds[endsOf(edges)]
+	if !ok {
+		return 0, 0, fmt.Errorf("edges %s are not a valid tile configuration", edges)
+	}
+	return shape.id, shape.rotation, nil
+}
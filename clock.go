@@ -0,0 +1,273 @@
+package go_tsuro
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+)
+
+// Clock tells the game the current time so turn timers can be driven by something other than the wall clock such as in tests
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// stoppedClock always reads the same time so turns never run out of time
+type stoppedClock struct {
+	now time.Time
+}
+
+func (c stoppedClock) Now() time.Time {
+	return c.now
+}
+
+// timer tracks how long the current turn has taken and the game time each team has left
+type timer struct {
+	clock    Clock
+	started  time.Time                // when the current turn started
+	left     map[string]time.Duration // game time left for each team
+	readings []*reading               // timers just after each action which are nil when not known
+}
+
+// reading is when an action was taken along with the timers just after it so the clocks can be shown as they were
+type reading struct {
+	At      time.Time
+	Started time.Time
+	Left    map[string]time.Duration `json:",omitempty"`
+}
+
+// SetClock changes the clock used to time turns and starts timing the current turn again
+func (t *Tsuro) SetClock(clock Clock) {
+	left := make(map[string]time.Duration)
+	var readings []*reading
+	if t.timer != nil {
+		left = t.timer.left
+		readings = t.timer.readings
+	} else if t.options.GameTime > 0 {
+		for _, team := range t.state.teams {
+			left[team] = t.options.GameTime
+		}
+	}
+	t.timer = &timer{
+		clock:    clock,
+		started:  clock.Now(),
+		left:     left,
+		readings: readings,
+	}
+}
+
+// Tick plays the timeout move for the team whose turn it is while they are out of time
+// it is called before every action but servers using timers should also call it periodically
+func (t *Tsuro) Tick() error {
+	for len(t.state.winners) == 0 && t.timedOut() {
+		if err := t.timeout(t.state.turn); err != nil {
+			return err
+		}
+	}
+	t.mark()
+	return nil
+}
+
+// mark records the time of each action taken since the last mark along with the timers just after it
+func (t *Tsuro) mark() {
+	if t.options.TurnTime == 0 && t.options.GameTime == 0 {
+		return
+	}
+	now := t.timer.clock.Now()
+	for len(t.timer.readings) < len(t.actions) {
+		left := make(map[string]time.Duration)
+		for team, l := range t.timer.left {
+			left[team] = l
+		}
+		t.timer.readings = append(t.timer.readings, &reading{At: now, Started: t.timer.started, Left: left})
+	}
+}
+
+// forget drops the readings of the actions after the first n when those actions are taken back or rewritten
+func (t *Tsuro) forget(n int) {
+	if len(t.timer.readings) > n {
+		t.timer.readings = t.timer.readings[:n]
+	}
+}
+
+// timerAt returns the timers stopped as they were just after the first n actions using the recorded times
+// or nil if the time of the nth action is not known
+func (t *Tsuro) timerAt(n int) *timer {
+	if n == 0 {
+		left := make(map[string]time.Duration)
+		if t.options.GameTime > 0 {
+			for _, team := range t.state.teams {
+				left[team] = t.options.GameTime
+			}
+		}
+		return &timer{clock: stoppedClock{}, left: left}
+	}
+	if n > len(t.timer.readings) || t.timer.readings[n-1] == nil {
+		return nil
+	}
+	r := t.timer.readings[n-1]
+	left := make(map[string]time.Duration)
+	for team, l := range r.Left {
+		left[team] = l
+	}
+	return &timer{clock: stoppedClock{now: r.At}, started: r.Started, left: left}
+}
+
+func (t *Tsuro) elapsed() time.Duration {
+	return t.timer.clock.Now().Sub(t.timer.started)
+}
+
+func (t *Tsuro) timedOut() bool {
+	elapsed := t.elapsed()
+	return (t.options.TurnTime > 0 && elapsed >= t.options.TurnTime) ||
+		(t.options.GameTime > 0 && elapsed >= t.timer.left[t.state.turn])
+}
+
+// endTurn charges the time taken to team and starts timing the next turn
+func (t *Tsuro) endTurn(team string) {
+	now := t.timer.clock.Now()
+	if t.options.GameTime > 0 {
+		left := t.timer.left[team] - now.Sub(t.timer.started)
+		if left < 0 {
+			left = 0
+		}
+		t.timer.left[team] = left
+	}
+	t.timer.started = now
+}
+
+// timeLeft returns the time left for the current turn and the game time left for each team
+func (t *Tsuro) timeLeft() (time.Duration, map[string]time.Duration) {
+	if t.options.TurnTime == 0 && t.options.GameTime == 0 {
+		return 0, nil
+	}
+	elapsed := t.elapsed()
+	var turn time.Duration
+	if t.options.TurnTime > 0 {
+		turn = t.options.TurnTime - elapsed
+	}
+	var game map[string]time.Duration
+	if t.options.GameTime > 0 {
+		game = make(map[string]time.Duration)
+		for team, left := range t.timer.left {
+			game[team] = left
+		}
+		game[t.state.turn] -= elapsed
+	}
+	for team, left := range game {
+		if left < 0 {
+			game[team] = 0
+		}
+	}
+	if turn < 0 {
+		turn = 0
+	}
+	return turn, game
+}
+
+// timeout plays the timeout move for team recording the timeout before any placement made for them
+func (t *Tsuro) timeout(team string) error {
+	move := t.options.TimeoutMove
+	var action *bg.BoardGameAction
+	if move != TimeoutForfeit {
+		if action = t.timeoutAction(team, move); action == nil {
+			// nothing can be placed so the team is out
+			move = TimeoutForfeit
+		}
+	}
+	t.actions = append(t.actions, &bg.BoardGameAction{
+		Team:        team,
+		ActionType:  ActionTimeout,
+		MoreDetails: TimeoutActionDetails{Move: move},
+	})
+	if move == TimeoutForfeit {
+		t.state.forfeit(team)
+	} else if err := t.do(action); err != nil {
+		return err
+	}
+	t.endTurn(team)
+	return nil
+}
+
+// timeoutAction picks the token or tile placement played for team when they run out of time
+// random choices do not use the game's randomness so replaying the recorded placement gives the same game
+func (t *Tsuro) timeoutAction(team, move string) *bg.BoardGameAction {
+	random := rand.New(rand.NewSource(t.options.Seed + int64(len(t.actions))))
+	if t.options.SecretSeed {
+		// moves picked using a secret seed would give away the seed
+		random = unseeded()
+	}
+	if t.state.placingTokens() {
+		tokens := t.state.startingTokens()
+		if len(tokens) == 0 {
+			return nil
+		}
+		token := tokens[random.Intn(len(tokens))]
+		return &bg.BoardGameAction{
+			Team:       team,
+			ActionType: ActionPlaceToken,
+			MoreDetails: PlaceTokenActionDetails{
+				Row:    token.Row,
+				Column: token.Col,
+				Notch:  token.Notch,
+			},
+		}
+	}
+	placements := t.state.legalPlacements(team)
+	if len(placements) == 0 {
+		return nil
+	}
+	placement := placements[random.Intn(len(placements))]
+	if move == TimeoutSafest {
+		placement = placements[0]
+		for _, p := range placements[1:] {
+			if (placement.SelfEliminating && !p.SelfEliminating) ||
+				(placement.SelfEliminating == p.SelfEliminating && len(p.Eliminated) < len(placement.Eliminated)) {
+				placement = p
+			}
+		}
+	}
+	return &bg.BoardGameAction{
+		Team:       team,
+		ActionType: ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{
+			Row:    placement.Row,
+			Column: placement.Column,
+			Tile:   placement.Tile,
+		},
+	}
+}
+
+// replayTimeout records a timeout from the game's history forfeiting if that is what happened
+// any placement made for the team is replayed by the action that follows
+func (t *Tsuro) replayTimeout(action *bg.BoardGameAction) error {
+	var details TimeoutActionDetails
+	if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+		return &bgerr.Error{
+			Err:    err,
+			Status: bgerr.StatusInvalidActionDetails,
+		}
+	}
+	if !contains(t.state.teams, action.Team) {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("%s not a valid team", action.Team),
+			Status: bgerr.StatusUnknownTeam,
+		}
+	}
+	t.actions = append(t.actions, action)
+	if details.Move == TimeoutForfeit {
+		t.state.forfeit(action.Team)
+	}
+	t.endTurn(action.Team)
+	t.mark()
+	return nil
+}
@@ -0,0 +1,169 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func Test_TsuroTimers(t *testing.T) {
+	_, err := NewTsuro(&bg.BoardGameOptions{
+		Teams:       []string{TeamA, TeamB},
+		MoreOptions: TsuroMoreOptions{TurnTime: time.Minute, TimeoutMove: "Resign"},
+	})
+	assert.Error(t, err)
+
+	tests := []struct {
+		name         string
+		options      TsuroMoreOptions
+		elapsed      time.Duration
+		timedOut     bool
+		forfeited    bool
+		placingToken bool
+	}{
+		{name: "no timers", options: TsuroMoreOptions{RandomTokens: true}, elapsed: time.Hour},
+		{name: "turn in time", options: TsuroMoreOptions{RandomTokens: true, TurnTime: time.Minute}, elapsed: 59 * time.Second},
+		{name: "random placement", options: TsuroMoreOptions{RandomTokens: true, TurnTime: time.Minute}, elapsed: time.Minute, timedOut: true},
+		{name: "safest placement", options: TsuroMoreOptions{RandomTokens: true, TurnTime: time.Minute, TimeoutMove: TimeoutSafest}, elapsed: time.Minute, timedOut: true},
+		{name: "forfeit", options: TsuroMoreOptions{RandomTokens: true, TurnTime: time.Minute, TimeoutMove: TimeoutForfeit}, elapsed: time.Minute, timedOut: true, forfeited: true},
+		{name: "token placement", options: TsuroMoreOptions{TurnTime: time.Minute}, elapsed: time.Minute, timedOut: true, placingToken: true},
+		{name: "game time", options: TsuroMoreOptions{RandomTokens: true, GameTime: time.Minute}, elapsed: time.Minute, timedOut: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tsuro := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, test.options)
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			tsuro.SetClock(clock)
+			clock.now = clock.now.Add(test.elapsed)
+			assert.NoError(t, tsuro.Tick())
+			if !test.timedOut {
+				assert.Empty(t, tsuro.actions)
+				assert.Equal(t, TeamA, tsuro.state.turn)
+				return
+			}
+			assert.Equal(t, TeamB, tsuro.state.turn)
+			assert.Equal(t, ActionTimeout, tsuro.actions[0].ActionType)
+			if test.forfeited {
+				assert.Len(t, tsuro.actions, 1)
+				assert.False(t, tsuro.state.alive[TeamA])
+			} else {
+				assert.Len(t, tsuro.actions, 2)
+				assert.Equal(t, TeamA, tsuro.actions[1].Team)
+				if test.placingToken {
+					assert.Equal(t, ActionPlaceToken, tsuro.actions[1].ActionType)
+				} else {
+					assert.Equal(t, ActionPlaceTile, tsuro.actions[1].ActionType)
+					assert.True(t, tsuro.state.alive[TeamA] || test.options.TimeoutMove != TimeoutSafest)
+				}
+			}
+			// the next team's turn has just started
+			assert.NoError(t, tsuro.Tick())
+			assert.Equal(t, TeamB, tsuro.state.turn)
+
+			// timeouts are part of the game's history
+			loaded, err := (&Builder{}).Load(tsuro.GetBGN())
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			assert.Equal(t, tsuro.GetBGN(), loaded.GetBGN())
+			assert.Equal(t, tsuro.state.alive, loaded.(*Tsuro).state.alive)
+			assert.Equal(t, tsuro.state.board, loaded.(*Tsuro).state.board)
+		})
+	}
+
+	// game time is used up across turns
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{RandomTokens: true, GameTime: time.Minute, TimeoutMove: TimeoutForfeit})
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	tsuro.SetClock(clock)
+	for _, team := range []string{TeamA, TeamB} {
+		clock.now = clock.now.Add(40 * time.Second)
+		placements, _ := tsuro.Placements(team)
+		assert.NoError(t, tsuro.Do(&bg.BoardGameAction{
+			Team:        team,
+			ActionType:  ActionPlaceTile,
+			MoreDetails: PlaceTileActionDetails{Row: placements[0].Row, Column: placements[0].Column, Tile: placements[0].Tile},
+		}))
+	}
+	assert.Empty(t, tsuro.state.winners)
+	snapshot, _ := tsuro.GetSnapshot()
+	assert.Equal(t, map[string]time.Duration{TeamA: 20 * time.Second, TeamB: 20 * time.Second}, snapshot.MoreData.(TsuroSnapshotData).GameTimeLeft)
+	clock.now = clock.now.Add(20 * time.Second)
+	placements, _ := tsuro.Placements(TeamA)
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:        TeamA,
+		ActionType:  ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{Row: placements[0].Row, Column: placements[0].Column, Tile: placements[0].Tile},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{TeamB}, tsuro.state.winners)
+}
+
+func Test_TsuroReplayTimers(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, TsuroMoreOptions{Seed: 3, RandomTokens: true, TurnTime: time.Minute, GameTime: 10 * time.Minute})
+	tsuro.SetClock(clock)
+	type times struct {
+		turn time.Duration
+		game map[string]time.Duration
+	}
+	read := func(snapshot *bg.BoardGameSnapshot) times {
+		data := snapshot.MoreData.(TsuroSnapshotData)
+		return times{turn: data.TurnTimeLeft, game: data.GameTimeLeft}
+	}
+	start, _ := tsuro.GetSnapshot()
+	recorded := []times{read(start)}
+	random := rand.New(rand.NewSource(3))
+	for i := 0; i < 3; i++ {
+		clock.now = clock.now.Add(time.Duration(10*(i+1)) * time.Second)
+		placements, _ := tsuro.Placements(tsuro.state.turn)
+		placement := placements[random.Intn(len(placements))]
+		assert.NoError(t, tsuro.Do(&bg.BoardGameAction{
+			Team:        tsuro.state.turn,
+			ActionType:  ActionPlaceTile,
+			MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+		}))
+		snapshot, _ := tsuro.GetSnapshot()
+		recorded = append(recorded, read(snapshot))
+	}
+	if len(tsuro.state.winners) > 0 {
+		t.Fatal("game should not be over")
+	}
+	clock.now = clock.now.Add(30 * time.Second)
+
+	// replays show the clocks as they were just after each action however long ago that was
+	data, _ := tsuro.MarshalStateBinary()
+	restored, err := UnmarshalState(data)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	for _, game := range []*Tsuro{tsuro, restored} {
+		for delay := 0; delay <= 3; delay++ {
+			replay, err := game.GetView(View{Mode: ViewReplay, Delay: delay})
+			assert.NoError(t, err)
+			assert.Equal(t, recorded[3-delay], read(replay))
+		}
+	}
+
+	// a game loaded from its BGN does not know when actions were taken so its replays show no clocks
+	builder := Builder{}
+	loaded, err := builder.Load(tsuro.GetBGN())
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	replay, _ := loaded.(*Tsuro).GetView(View{Mode: ViewReplay, Delay: 1})
+	assert.Equal(t, times{}, read(replay))
+}
@@ -0,0 +1,86 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"time"
+
+	bg "github.com/quibbble/go-boardgame"
+)
+
+// Clone returns a deep copy of the game, including the position of its random stream, that can be played independently
+// so the copy draws the same tiles as the game would for the same actions
+// observers are not copied
+func (t *Tsuro) Clone() *Tsuro {
+	source := newSource(t.source.seed)
+	source.advance(t.source.drawn)
+	options := *t.options
+	left := make(map[string]time.Duration)
+	for team, l := range t.timer.left {
+		left[team] = l
+	}
+	return &Tsuro{
+		state:   t.state.clone(rand.New(source)),
+		actions: append([]*bg.BoardGameAction{}, t.actions...),
+		options: &options,
+		source:  source,
+		timer: &timer{
+			clock:    t.timer.clock,
+			started:  t.timer.started,
+			left:     left,
+			readings: append([]*reading{}, t.timer.readings...),
+		},
+		script:  t.script,
+		nonce:   t.nonce,
+		rotated: t.rotated,
+	}
+}
+
+// clone deep copies the state giving the copied deck random in place of the shared random
+// teams sharing a hand still share the copied hand
+func (s *state) clone(random *rand.Rand) *state {
+	tokens := make(map[string]*token)
+	for team, token := range s.tokens {
+		copied := *token
+		tokens[team] = &copied
+	}
+	hands := make(map[string]*hand)
+	copied := make(map[*hand]*hand)
+	for team, h := range s.hands {
+		if _, ok := copied[h]; !ok {
+			copied[h] = &hand{hand: copyTiles(h.hand)}
+		}
+		hands[team] = copied[h]
+	}
+	var daikaiju []*daikaiju
+	for _, d := range s.daikaiju {
+		copied := *d
+		daikaiju = append(daikaiju, &copied)
+	}
+	return &state{
+		turn:    s.turn,
+		teams:   append([]string{}, s.teams...),
+		winners: append([]string{}, s.winners...),
+		board:   s.board.copy(),
+		deck: &deck{
+			deck:    copyTiles(s.deck.deck),
+			random:  random,
+			drawn:   append([]string{}, s.deck.drawn...),
+			stacked: append([]string{}, s.deck.stacked...),
+		},
+		tokens:          tokens,
+		hands:           hands,
+		dragon:          s.dragon,
+		playedFirstTurn: copyBools(s.playedFirstTurn),
+		alive:           copyBools(s.alive),
+		variant:         s.variant,
+		points:          copyInts(s.points),
+		noSuicide:       s.noSuicide,
+		alliances:       s.alliances,
+		daikaiju:        daikaiju,
+		destroyed:       copyTiles(s.destroyed),
+		daikaijuMove:    s.daikaijuMove,
+		scriptedMoves:   append([]*MoveDaikaijuActionDetails{}, s.scriptedMoves...),
+		paths:           copyPaths(s.paths),
+		events:          copyEvents(s.events),
+	}
+}
@@ -0,0 +1,69 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroClone(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TsuroMoreOptions
+	}{
+		{name: "classic", options: TsuroMoreOptions{Variant: VariantClassic}},
+		{name: "most crossings", options: TsuroMoreOptions{Variant: VariantMostCrossings}},
+		{name: "seas", options: TsuroMoreOptions{Variant: VariantSeas}},
+		{name: "partners", options: TsuroMoreOptions{Variant: VariantPartners, Alliances: [][]string{{TeamA, "TeamC"}, {TeamB, "TeamD"}}}},
+		{name: "duplicates", options: TsuroMoreOptions{Variant: VariantClassic, Tiles: append(append([]string{}, tiles[:15]...), tiles[:15]...)}},
+	}
+	teams := []string{TeamA, TeamB, "TeamC", "TeamD"}
+	play := func(game *Tsuro, random *rand.Rand, placements int) {
+		for i := 0; i < placements && len(game.state.winners) == 0; i++ {
+			targets, _ := game.Placements(game.state.turn)
+			target := targets[random.Intn(len(targets))]
+			err := game.Do(&bg.BoardGameAction{
+				Team:        game.state.turn,
+				ActionType:  ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{Row: target.Row, Column: target.Column, Tile: target.Tile},
+			})
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+		}
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := test.options
+			options.Seed = 11
+			options.RandomTokens = true
+			game := newTestTsuro(t, teams, options)
+			play(game, rand.New(rand.NewSource(1)), 3)
+			game.SetClock(&fakeClock{now: time.Unix(0, 0)})
+			before, _ := game.MarshalStateBinary()
+
+			// the clone plays on without changing the game
+			clone := game.Clone()
+			cloned, _ := clone.MarshalStateBinary()
+			assert.Equal(t, before, cloned)
+			play(clone, rand.New(rand.NewSource(2)), 100)
+			after, _ := game.MarshalStateBinary()
+			assert.Equal(t, before, after)
+			if test.options.Variant == VariantPartners {
+				assert.Same(t, clone.state.hands[TeamA], clone.state.hands["TeamC"])
+				assert.NotSame(t, game.state.hands[TeamA], clone.state.hands[TeamA])
+			}
+
+			// the game continues identically including its randomness
+			play(game, rand.New(rand.NewSource(2)), 100)
+			expected, _ := game.GetSnapshot()
+			actual, _ := clone.GetSnapshot()
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, game.GetBGN(), clone.GetBGN())
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package go_tsuro
+
+import "math/rand"
+
+const (
+	seasBoardSize = 9 // Tsuro of the Seas is played on a 9x9 board
+	seasDaikaiju  = 3 // number of daikaiju placed at the start of the game
+)
+
+// wakeTiles are the 35 wake tiles Tsuro of the Seas is played with
+// each joins the notches in one of the ways a path tile can so every wake tile is also in the catalogue
+var wakeTiles = []string{
+	"ABCDEFGH", "AHBGCDEF", "AHBCDGEF", "AHBCDEFG", "AGBHCDEF",
+	"ABCHDGEF", "ABCGDHEF", "AGBCDHEF", "ABCGDEFH", "AGBCDEFH",
+	"ACBGDEFH", "ACBGDHEF", "ACBHDGEF", "ADBHCGEF", "ADBGCHEF",
+	"ADBCEHFG", "ADBCEGFH", "AEBCDGFH", "AEBCDHFG", "AFBHCDEG",
+	"AFBGCHDE", "AFBCDHEG", "AFBDCHEG", "AFBDCGEH", "AEBDCGFH",
+	"ACBDEGFH", "AFBECHDG", "AFBECGDH", "AEBFCGDH", "ADBFCGEH",
+	"ADBFCHEG", "ACBFDHEG", "ADBGCEFH", "AGBDCEFH", "ADBGCFEH",
+}
+
+// daikaiju is a sea monster that roams the board destroying any tiles and tokens in its way
+type daikaiju struct {
+	Row int
+	Col int
+}
+
+// randomDaikaiju places count daikaiju on distinct squares away from the board edge where tokens start
+func randomDaikaiju(random *rand.Rand, rows, columns, count int) []*daikaiju {
+	result := make([]*daikaiju, 0)
+	for len(result) < count {
+		d := &daikaiju{
+			Row: 1 + random.Intn(rows-2),
+			Col: 1 + random.Intn(columns-2),
+		}
+		unique := true
+		for _, other := range result {
+			if d.Row == other.Row && d.Col == other.Col {
+				unique = false
+			}
+		}
+		if unique {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// awakens is true when the roll of two dice wakes the daikaiju
+func awakens(roll int) bool {
+	return roll >= 6 && roll <= 8
+}
+
+// direction returns the square a daikaiju moves to for a die roll of 1 north, 2 east, 3 south, 4 west, or 5 and 6 to stay put
+func (d *daikaiju) direction(roll int) (int, int) {
+	switch roll {
+	case 1:
+		return d.Row - 1, d.Col
+	case 2:
+		return d.Row, d.Col + 1
+	case 3:
+		return d.Row + 1, d.Col
+	case 4:
+		return d.Row, d.Col - 1
+	}
+	return d.Row, d.Col
+}
+
+func (m *MoveDaikaijuActionDetails) equals(m2 *MoveDaikaijuActionDetails) bool {
+	if m.Roll != m2.Roll || len(m.Directions) != len(m2.Directions) {
+		return false
+	}
+	for idx, direction := range m.Directions {
+		if direction != m2.Directions[idx] {
+			return false
+		}
+	}
+	return true
+}
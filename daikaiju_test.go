@@ -0,0 +1,68 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroSeas(t *testing.T) {
+	_, err := NewTsuro(&bg.BoardGameOptions{
+		Teams: []string{TeamA, TeamB},
+		MoreOptions: TsuroMoreOptions{
+			Variant:   VariantSeas,
+			BoardSize: defaultBoardSize,
+		},
+	})
+	assert.Error(t, err)
+
+	for seed := int64(0); seed < 5; seed++ {
+		tsuro := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, TsuroMoreOptions{
+			Seed:         seed,
+			Variant:      VariantSeas,
+			RandomTokens: true,
+		})
+		assert.Len(t, tsuro.state.board.board, seasBoardSize)
+		assert.Len(t, tsuro.state.daikaiju, seasDaikaiju)
+
+		random := rand.New(rand.NewSource(seed))
+		for len(tsuro.state.winners) == 0 {
+			placements, _ := tsuro.Placements(tsuro.state.turn)
+			placement := placements[random.Intn(len(placements))]
+			err := tsuro.Do(&bg.BoardGameAction{
+				Team:       tsuro.state.turn,
+				ActionType: ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{
+					Row:    placement.Row,
+					Column: placement.Column,
+					Tile:   placement.Tile,
+				},
+			})
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			// daikaiju roll after every placement until they have all left the board
+			if len(tsuro.state.winners) == 0 && tsuro.actions[len(tsuro.actions)-1].ActionType != ActionMoveDaikaiju {
+				assert.Empty(t, tsuro.state.daikaiju)
+			}
+		}
+
+		builder := Builder{}
+		loaded, err := builder.Load(tsuro.GetBGN())
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		assert.Equal(t, tsuro.state.daikaiju, loaded.(*Tsuro).state.daikaiju)
+		assert.Equal(t, tsuro.state.winners, loaded.(*Tsuro).state.winners)
+		assert.Len(t, loaded.(*Tsuro).actions, len(tsuro.actions))
+
+		// undo takes back the last placement along with the daikaiju move it caused
+		placed := tsuro.state.board.getTileCount() + len(tsuro.state.destroyed)
+		assert.NoError(t, tsuro.Undo(1))
+		assert.Equal(t, placed-1, tsuro.state.board.getTileCount()+len(tsuro.state.destroyed))
+	}
+}
@@ -2,23 +2,42 @@ package go_tsuro
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
 )
 
 type deck struct {
-	deck   []*tile
-	random *rand.Rand
+	deck     []*tile
+	random   *rand.Rand
+	drawn    []string        // tiles drawn in order
+	stacked  []string        // tiles that must be drawn next in order when replaying a game without its seed
+	draws    map[*tile][]int // where in drawn each tile was drawn which is only kept by games dealt from the start
+	placed   []int           // where in drawn the tiles since placed were drawn
+	reserved map[*tile]bool  // tiles kept for the recorded draws of placed tiles when replaying draws that are partly hidden
 }
 
-func newDeck(random *rand.Rand) *deck {
+// deckTiles returns the edges of the tiles a game with options is played with
+func deckTiles(options *TsuroMoreOptions) []string {
+	if len(options.Tiles) > 0 {
+		return options.Tiles
+	}
+	if options.Variant == VariantSeas {
+		return wakeTiles
+	}
+	return tiles
+}
+
+// newDeck shuffles a deck holding a tile for each edges in set
+func newDeck(random *rand.Rand, set []string) *deck {
 	d := make([]*tile, 0)
-	for _, edges := range tiles {
+	for _, edges := range set {
 		t, _ := newTile(edges)
 		d = append(d, t)
 	}
 	result := &deck{
 		deck:   d,
 		random: random,
+		draws:  make(map[*tile][]int),
 	}
 	result.Shuffle()
 	return result
@@ -44,11 +63,108 @@ func (d *deck) Draw() (*tile, error) {
 	if size <= 0 {
 		return nil, errors.New("deck is empty so cannot draw")
 	}
-	tile := d.deck[size-1]
-	d.deck = d.deck[:size-1]
+	idx := size - 1
+	if len(d.stacked) > 0 {
+		next, err := newTile(d.stacked[0])
+		if d.stacked[0] == hiddenDraw {
+			idx = d.spare()
+		} else if err != nil {
+			return nil, err
+		} else if idx = d.find(next); idx < 0 {
+			return nil, fmt.Errorf("tile %s is not in the deck so cannot draw", d.stacked[0])
+		} else {
+			// a tile returned to the deck from a hand may have been drawn in another orientation
+			d.deck[idx].Edges, d.deck[idx].shape = next.Edges, next.shape
+		}
+		d.stacked = d.stacked[1:]
+	}
+	tile := d.deck[idx]
+	d.deck = append(d.deck[:idx], d.deck[idx+1:]...)
+	if d.draws != nil {
+		d.draws[tile] = append(d.draws[tile], len(d.drawn))
+	}
+	d.drawn = append(d.drawn, tile.Edges)
 	return tile, nil
 }
 
+// place records that tile has left hand for the board
+// when replaying partly hidden draws a copy kept for placed tiles left in hand is the one placed in place of an identical spare
+func (d *deck) place(tile *tile, hand []*tile) {
+	if d.reserved != nil && !d.reserved[tile] {
+		for _, other := range hand {
+			if d.reserved[other] && other.shape.id == tile.shape.id {
+				d.reserved[other], d.reserved[tile] = false, true
+				d.draws[other], d.draws[tile] = d.draws[tile], d.draws[other]
+				break
+			}
+		}
+	}
+	d.placed = append(d.placed, d.draws[tile]...)
+}
+
+// find returns the index of the tile in the deck with the same paths as t or -1 if there is none
+// preferring tiles kept for placed tiles and then those with the same edges
+func (d *deck) find(t *tile) int {
+	idx, best := -1, -1
+	for i, other := range d.deck {
+		if other.shape.id != t.shape.id {
+			continue
+		}
+		score := 0
+		if d.reserved[other] {
+			score += 2
+		}
+		if other.Edges == t.Edges {
+			score++
+		}
+		if score >= best {
+			idx, best = i, score
+		}
+	}
+	return idx
+}
+
+// spare returns the index of a tile to draw in place of a hidden draw leaving the tiles kept for placed tiles
+func (d *deck) spare() int {
+	for i := len(d.deck) - 1; i >= 0; i-- {
+		if !d.reserved[d.deck[i]] {
+			return i
+		}
+	}
+	return len(d.deck) - 1
+}
+
+// guessed is true if hand holds a tile drawn in place of a hidden draw so what the team really held is unknown
+func (d *deck) guessed(hand []*tile) bool {
+	if d.reserved == nil {
+		return false
+	}
+	for _, tile := range hand {
+		if !d.reserved[tile] {
+			return true
+		}
+	}
+	return false
+}
+
+// reserve keeps a tile with the same paths as each of placed for the draws of those tiles
+// so the tiles drawn in place of hidden draws can never be ones that are placed later
+func (d *deck) reserve(placed []string) {
+	d.reserved = make(map[*tile]bool)
+	for _, edges := range placed {
+		t, err := newTile(edges)
+		if err != nil {
+			continue
+		}
+		for _, other := range d.deck {
+			if !d.reserved[other] && other.shape.id == t.shape.id {
+				d.reserved[other] = true
+				break
+			}
+		}
+	}
+}
+
 func (d *deck) Shuffle() {
 	for i := 0; i < len(d.deck); i++ {
 		r := d.random.Intn(len(d.deck))
@@ -0,0 +1,61 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroTileSets(t *testing.T) {
+	tests := []struct {
+		name  string
+		tiles []string
+		err   bool
+	}{
+		{name: "default", tiles: nil},
+		{name: "subset", tiles: []string{"ABCDEFGH", "AHBGCDEF", "AHBCDGEF", "AHBCDEFG", "AGBHCDEF", "ABCHDGEF", "ABCGDHEF", "AGBCDHEF"}},
+		{name: "duplicates", tiles: []string{"ABCDEFGH", "ABCDEFGH", "ABCDEFGH", "ABCDEFGH", "CDEFGHAB", "CDEFGHAB", "ADBGCFEH", "ADBGCFEH", "ADBGCFEH"}},
+		{name: "invalid tile", tiles: []string{"ABCDEFGH", "AABBCCDD", "AHBCDGEF", "AHBCDEFG", "AGBHCDEF", "ABCHDGEF"}, err: true},
+		{name: "too few to deal", tiles: []string{"ABCDEFGH", "AHBGCDEF", "AHBCDGEF", "AHBCDEFG", "AGBHCDEF"}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game, err := NewTsuro(&bg.BoardGameOptions{
+				Teams:       []string{TeamA, TeamB},
+				MoreOptions: TsuroMoreOptions{Seed: 9, RandomTokens: true, Tiles: test.tiles},
+			})
+			assert.Equal(t, test.err, err != nil)
+			if test.err {
+				return
+			}
+			size := len(test.tiles)
+			if size == 0 {
+				size = 35
+			}
+			random := rand.New(rand.NewSource(9))
+			for len(game.state.winners) == 0 {
+				placements, _ := game.Placements(game.state.turn)
+				placement := placements[random.Intn(len(placements))]
+				assert.NoError(t, game.Do(&bg.BoardGameAction{
+					Team:        game.state.turn,
+					ActionType:  ActionPlaceTile,
+					MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+				}))
+				count := len(game.state.deck.deck) + game.state.board.getTileCount()
+				for _, hand := range game.state.hands {
+					count += len(hand.hand)
+				}
+				assert.Equal(t, size, count)
+
+				loaded, err := (&Builder{}).Load(game.GetBGN())
+				assert.NoError(t, err)
+				assert.Equal(t, game.GetBGN(), loaded.GetBGN())
+				data, _ := game.MarshalStateBinary()
+				_, err = UnmarshalState(data)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
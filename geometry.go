@@ -0,0 +1,128 @@
+package go_tsuro
+
+import "fmt"
+
+// notch is one of the eight places a path meets the side of a square numbered clockwise from A as drawn in tile.go
+type notch uint8
+
+const (
+	notchA notch = iota
+	notchB
+	notchC
+	notchD
+	notchE
+	notchF
+	notchG
+	notchH
+	notchCount // number of notches on a square
+)
+
+const notchNames = "ABCDEFGH"
+
+var (
+	// facing is the notch on the neighbouring square that touches each notch
+	facing = [notchCount]notch{notchF, notchE, notchH, notchG, notchB, notchA, notchD, notchC}
+	// steps is the row and column offset of the neighbouring square each notch faces
+	steps = [notchCount][2]int{{-1, 0}, {-1, 0}, {0, 1}, {0, 1}, {1, 0}, {1, 0}, {0, -1}, {0, -1}}
+
+	shapes        = newShapes()        // every tile in the catalogue in each of its four rotations
+	shapesByEdges = newShapesByEdges() // shape of every spelling of edges a tile can have
+	shapesByEnds  = newShapesByEnds()  // shape with the fewest rotations for the connections of each orientation
+)
+
+// toNotch reads the notch at the start of s which must be one of A to H
+func toNotch(s string) notch {
+	return notch(s[0] - 'A')
+}
+
+func (n notch) String() string {
+	return notchNames[n : n+1]
+}
+
+// shape is a tile in the catalogue rotated right some number of times from its canonical orientation
+type shape struct {
+	id       int               // catalogue id of the tile
+	rotation int               // right rotations from the canonical orientation
+	edges    string            // canonical edges with each notch moved two places clockwise per rotation
+	ends     [notchCount]notch // notch each notch connects to
+}
+
+func newShapes() [][4]shape {
+	result := make([][4]shape, len(tiles))
+	for idx, edges := range tiles {
+		for r := 0; r < 4; r++ {
+			result[idx][r] = shape{
+				id:       idx + 1,
+				rotation: r,
+				edges:    edges,
+				ends:     endsOf(edges),
+			}
+			edges = rotate(edges, 2)
+		}
+	}
+	return result
+}
+
+func newShapesByEdges() map[string]*shape {
+	result := make(map[string]*shape)
+	for idx := range shapes {
+		for r := range shapes[idx] {
+			result[shapes[idx][r].edges] = &shapes[idx][r]
+		}
+	}
+	return result
+}
+
+func newShapesByEnds() map[[notchCount]notch]*shape {
+	result := make(map[[notchCount]notch]*shape)
+	for idx := range shapes {
+		for r := range shapes[idx] {
+			if _, ok := result[shapes[idx][r].ends]; !ok {
+				result[shapes[idx][r].ends] = &shapes[idx][r]
+			}
+		}
+	}
+	return result
+}
+
+// shapeOf returns the shape of edges which must be spelt as the tile in the catalogue rotated right
+func shapeOf(edges string) (*shape, error) {
+	s, ok := shapesByEdges[edges]
+	if !ok {
+		return nil, fmt.Errorf("edges %s are not a valid tile configuration", edges)
+	}
+	return s, nil
+}
+
+// endsOf lists the notch each notch connects to in edges which must hold each notch once
+func endsOf(edges string) [notchCount]notch {
+	var ends [notchCount]notch
+	for i := 0; i+1 < len(edges); i += 2 {
+		a, b := toNotch(edges[i:]), toNotch(edges[i+1:])
+		ends[a], ends[b] = b, a
+	}
+	return ends
+}
+
+// rotate moves every notch in edges steps notches clockwise
+func rotate(edges string, steps byte) string {
+	rotated := make([]byte, len(edges))
+	for i := 0; i < len(edges); i++ {
+		rotated[i] = 'A' + (edges[i]-'A'+steps)%8
+	}
+	return string(rotated)
+}
+
+// rotated returns the shape turned right turns times
+func (s *shape) rotated(turns int) *shape {
+	return &shapes[s.id-1][(s.rotation+turns)%4]
+}
+
+// crosses is true if the path from a to b crosses the path from c to d on the same tile
+// which happens when exactly one end of the second path lies between the ends of the first going clockwise
+func crosses(a, b, c, d notch) bool {
+	if a > b {
+		a, b = b, a
+	}
+	return (c > a && c < b) != (d > a && d < b)
+}
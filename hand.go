@@ -15,20 +15,26 @@ func (h *hand) Add(tiles ...*tile) {
 }
 
 func (h *hand) Remove(tile *tile) error {
-	for idx, t := range h.hand {
-		if tile.equals(t) {
-			h.hand = append(h.hand[:idx], h.hand[idx+1:]...)
-			return nil
-		}
+	idx := h.IndexOf(tile)
+	if idx < 0 {
+		return errors.New("tile not found")
 	}
-	return errors.New("tile not found")
+	h.hand = append(h.hand[:idx], h.hand[idx+1:]...)
+	return nil
 }
 
 func (h *hand) Clear() {
 	h.hand = make([]*tile, 0)
 }
 
+// IndexOf returns the index of the first tile in the same orientation as tile or failing that the first copy of tile
+// so each copy in a deck with duplicates can be picked out by its orientation
 func (h *hand) IndexOf(tile *tile) int {
+	for idx, t := range h.hand {
+		if tile.Edges == t.Edges {
+			return idx
+		}
+	}
 	for idx, t := range h.hand {
 		if tile.equals(t) {
 			return idx
@@ -36,3 +42,11 @@ func (h *hand) IndexOf(tile *tile) int {
 	}
 	return -1
 }
+
+// index returns the position in hand the rotation picks out or -1 when it names the tile alone
+func (r *RotateTileActionDetails) index() int {
+	if r.Index == nil {
+		return -1
+	}
+	return *r.Index
+}
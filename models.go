@@ -1,10 +1,17 @@
 package go_tsuro
 
+import "time"
+
 // Action types
 const (
+	ActionPlaceToken      = "PlaceToken"
 	ActionPlaceTile       = "PlaceTile"
 	ActionRotateTileRight = "RotateTileRight"
 	ActionRotateTileLeft  = "RotateRileLeft"
+	ActionUndo            = "Undo"
+	ActionMoveDaikaiju    = "MoveDaikaiju" // performed automatically after each placement in the Seas variant
+	ActionTimeout         = "Timeout"      // performed automatically when a team runs out of time
+	ActionResign          = "Resign"
 )
 
 // Tsuro Variants
@@ -14,41 +21,153 @@ const (
 	VariantMostCrossings = "MostCrossings" // player whose path crosses itself the most wins
 	VariantOpenTiles     = "OpenTiles"     // tiles are shared globally
 	VariantSolo          = "Solo"          // place tiles while keeping all tokens on the board
+	VariantSeas          = "Seas"          // Tsuro of the Seas on a 9x9 board with daikaiju destroying tiles and tokens
+	VariantPartners      = "Partners"      // teams in the same alliance share a hand and win together
+)
+
+var variants = []string{VariantClassic, VariantLongestPath, VariantMostCrossings, VariantOpenTiles, VariantSolo, VariantSeas, VariantPartners}
+
+// Event types
+const (
+	EventTilePlaced    = "TilePlaced"    // Team placed Tile at Row and Column
+	EventTokenMoved    = "TokenMoved"    // Team's token travelled along Segment
+	EventCollided      = "Collided"      // Team's token collided with the tokens of Teams
+	EventOffEdge       = "OffEdge"       // Team's token went off the edge of the board
+	EventDaikaiju      = "Daikaiju"      // Team's token was knocked out by a daikaiju
+	EventLooped        = "Looped"        // Team's token went round a loop back to where it started
+	EventForfeit       = "Forfeit"       // Team resigned or was knocked out by running out of time
+	EventTileDestroyed = "TileDestroyed" // a daikaiju destroyed Tile at Row and Column
+	EventTileDrawn     = "TileDrawn"     // Team drew Tile which is hidden from other teams
+	EventDragonPassed  = "DragonPassed"  // the dragon tile passed to Team or went back to the box when Team is empty
+)
+
+// Moves played for a team that runs out of time
+const (
+	TimeoutRandom  = "Random"  // place a random legal tile or token
+	TimeoutSafest  = "Safest"  // place the tile that keeps the team on the board while knocking off the fewest others
+	TimeoutForfeit = "Forfeit" // the team is knocked out of the game
 )
 
-var variants = []string{VariantClassic, VariantLongestPath, VariantMostCrossings, VariantOpenTiles, VariantSolo}
+var timeoutMoves = []string{TimeoutRandom, TimeoutSafest, TimeoutForfeit}
+
+// View modes
+const (
+	ViewPlayer     = "Player"     // what Team sees with only the hand they hold
+	ViewSpectator  = "Spectator"  // public information with every hand hidden
+	ViewOmniscient = "Omniscient" // everything including every hand and the order of the deck
+	ViewReplay     = "Replay"     // what a spectator saw Delay actions ago
+)
+
+var viewModes = []string{ViewPlayer, ViewSpectator, ViewOmniscient, ViewReplay}
+
+// View chooses how much of the game a snapshot exposes
+type View struct {
+	Mode  string
+	Team  string // team whose view is shown in the Player mode
+	Delay int    // number of the latest actions left out of the Replay mode
+}
 
 // TsuroMoreOptions are the additional options for creating a game of Tsuro
 type TsuroMoreOptions struct {
-	Seed    int64
-	Variant string
+	Seed              int64
+	Variant           string
+	RandomTokens      bool          // tokens are randomly placed instead of each team choosing a starting notch
+	CollapseRotations bool          // consecutive rotations of the same tile are recorded as their net rotation
+	BoardSize         int           // number of rows and columns on the board which defaults to 6
+	NoSuicide         bool          // placements that knock the placing team off the board are not allowed unless every placement does
+	Alliances         [][]string    // groups of teams playing together in the Partners variant
+	TurnTime          time.Duration // time each team has to make each placement with zero meaning no limit
+	GameTime          time.Duration // total time each team has across all their turns with zero meaning no limit
+	TimeoutMove       string        // move played when a team runs out of time which defaults to Random
+	SecretSeed        bool          // the BGN records a salted hash of the seed instead of the seed and drawn tiles until the game is over
+	Tiles             []string      // edges of the tiles in the deck which defaults to one of each tile in the catalogue
 }
 
 // TsuroMoreInfo provides additional info about the game
 type TsuroMoreInfo struct {
-	Variants []string
+	Variants                   []string
+	MinBoardSize, MaxBoardSize int
 }
 
 // RotateTileActionDetails is the action details for rotating a tile in hand
 type RotateTileActionDetails struct {
-	Tile string
+	Tile  string
+	Index *int `json:",omitempty"` // position of the tile in hand which picks out one of several copies of the same tile
+}
+
+// PlaceTokenActionDetails is the action details for placing a token on a starting notch at the edge of the board
+type PlaceTokenActionDetails struct {
+	Row, Column int // always written so placements on the first row or column keep their position
+	Notch       string
 }
 
 // PlaceTileActionDetails is the action details for placing a tile in the desired location on the board
 type PlaceTileActionDetails struct {
-	Row, Column int
+	Row, Column int // always written so placements on the first row or column keep their position
 	Tile        string
 }
 
+// UndoActionDetails is the action details for taking back the last Actions actions which defaults to one
+type UndoActionDetails struct {
+	Actions int
+}
+
+// MoveDaikaijuActionDetails records the two dice rolled after a placement in the Seas variant
+// and when those wake the daikaiju the die rolled for each daikaiju in order
+type MoveDaikaijuActionDetails struct {
+	Roll       int
+	Directions []int
+}
+
+// PlacementTarget is a legal tile placement along with the teams the placement would knock off the board
+type PlacementTarget struct {
+	Row, Column     int
+	Tile            string
+	Eliminated      []string // teams eliminated by the placement
+	SelfEliminating bool     // whether the placing team is one of the eliminated teams
+}
+
+// TimeoutActionDetails records that a team ran out of time and the move played for them
+// any placement is recorded as the following action
+type TimeoutActionDetails struct {
+	Move string
+}
+
+// PathSegment is the part of a token's route across a single tile from the notch it entered to the notch it left
+type PathSegment struct {
+	Row, Column int // always written so placements on the first row or column keep their position
+	Entry, Exit string
+}
+
+// Event is something that happened during the latest placement
+type Event struct {
+	Type        string
+	Team        string       `json:",omitempty"`
+	Row, Column int          // always written so placements on the first row or column keep their position
+	Tile        string       `json:",omitempty"`
+	Segment     *PathSegment `json:",omitempty"`
+	Teams       []string     `json:",omitempty"`
+}
+
 // TsuroSnapshotData is the game data unique to Tsuro
 type TsuroSnapshotData struct {
 	Board          [][]*tile
 	TilesRemaining int
-	Hands          map[string][]*tile
+	Hands          map[string][]*tile // hands visible in the view
+	HandSizes      map[string]int     // number of tiles in every hand
+	Deck           []*tile            `json:",omitempty"` // deck in draw order with the next tile drawn last only in the omniscient view
 	Tokens         map[string]*token
-	Dragon         string `json:",omitempty"`
+	Alive          []string
+	Paths          map[string][]*PathSegment // route each token has travelled in order
+	Alliances      [][]string                `json:",omitempty"`
+	Dragon         string                    `json:",omitempty"`
+	Daikaiju       []*daikaiju               `json:",omitempty"`
 	Variant        string
-	Points         map[string]int `json:",omitempty"`
+	Points         map[string]int           `json:",omitempty"`
+	Placements     []*PlacementTarget       `json:",omitempty"`
+	Events         []*Event                 `json:",omitempty"` // what happened during the latest placement in order
+	TurnTimeLeft   time.Duration            `json:",omitempty"` // time left for the current turn
+	GameTimeLeft   map[string]time.Duration `json:",omitempty"` // time left for each team across the rest of the game
 }
 
 // list of all the tiles that can be played
@@ -61,79 +180,3 @@ var tiles = []string{
 	"ACBDEGFH", "AFBECHDG", "AFBECGDH", "AEBFCGDH", "ADBFCGEH",
 	"ADBFCHEG", "ACBFDHEG", "ADBGCEFH", "AGBDCEFH", "ADBGCFEH",
 }
-
-// map of path to list of all other paths that cross the path
-var crossing = map[string][]string{
-	"AB": {}, "BA": {},
-	"CD": {}, "DC": {},
-	"EF": {}, "FE": {},
-	"GH": {}, "HG": {},
-
-	"AC": {"BD", "BE", "BF", "BG", "BH", "DB", "EB", "FB", "GB", "HB"},
-	"CA": {"BD", "BE", "BF", "BG", "BH", "DB", "EB", "FB", "GB", "HB"},
-
-	"AD": {"BE", "BF", "BG", "BH", "CE", "CF", "CG", "CH", "EB", "FB", "GB", "HB", "EC", "FC", "GC", "HC"},
-	"DA": {"BE", "BF", "BG", "BH", "CE", "CF", "CG", "CH", "EB", "FB", "GB", "HB", "EC", "FC", "GC", "HC"},
-
-	"AE": {"BF", "BG", "BH", "CF", "CG", "CH", "DF", "DG", "DH", "FB", "GB", "HB", "FC", "GC", "HC", "FD", "GD", "HD"},
-	"EA": {"BF", "BG", "BH", "CF", "CG", "CH", "DF", "DG", "DH", "FB", "GB", "HB", "FC", "GC", "HC", "FD", "GD", "HD"},
-
-	"AF": {"BG", "BH", "CG", "CH", "DG", "DH", "EG", "EH", "GB", "HB", "GC", "HC", "GD", "HD", "GE", "HE"},
-	"FA": {"BG", "BH", "CG", "CH", "DG", "DH", "EG", "EH", "GB", "HB", "GC", "HC", "GD", "HD", "GE", "HE"},
-
-	"AG": {"HB", "HC", "HD", "HE", "HF", "BH", "CH", "DH", "EH", "FH"},
-	"GA": {"HB", "HC", "HD", "HE", "HF", "BH", "CH", "DH", "EH", "FH"},
-
-	"AH": {}, "HA": {},
-
-	"BC": {}, "CB": {},
-
-	"BD": {"CA", "CE", "CF", "CG", "CH", "AC", "EC", "FC", "GC", "HC"},
-	"DB": {"CA", "CE", "CF", "CG", "CH", "AC", "EC", "FC", "GC", "HC"},
-
-	"BE": {"AC", "AD", "FC", "FD", "GC", "GD", "HC", "HD", "CA", "DA", "CF", "DF", "CG", "DG", "CH", "DH"},
-	"EB": {"AC", "AD", "FC", "FD", "GC", "GD", "HC", "HD", "CA", "DA", "CF", "DF", "CG", "DG", "CH", "DH"},
-
-	"BF": {"AE", "AC", "AD", "HE", "HC", "HD", "GE", "GC", "GD", "EA", "CA", "DA", "EH", "CH", "DH", "EG", "CG", "DG"},
-	"FB": {"AE", "AC", "AD", "HE", "HC", "HD", "GE", "GC", "GD", "EA", "CA", "DA", "EH", "CH", "DH", "EG", "CG", "DG"},
-
-	"BG": {"AC", "AD", "AE", "AF", "HC", "HD", "HE", "HF", "CA", "DA", "EA", "FA", "CH", "DH", "EH", "FH"},
-	"GB": {"AC", "AD", "AE", "AF", "HC", "HD", "HE", "HF", "CA", "DA", "EA", "FA", "CH", "DH", "EH", "FH"},
-
-	"BH": {"AC", "AD", "AE", "AF", "AG", "CA", "DA", "EA", "FA", "GA"},
-	"HB": {"AC", "AD", "AE", "AF", "AG", "CA", "DA", "EA", "FA", "GA"},
-
-	"CE": {"DA", "DB", "DF", "DG", "DH", "AD", "BD", "FD", "GD", "HD"},
-	"EC": {"DA", "DB", "DF", "DG", "DH", "AD", "BD", "FD", "GD", "HD"},
-
-	"CF": {"DA", "DB", "DG", "DH", "EA", "EB", "EG", "EH", "AD", "BD", "GD", "HD", "AE", "BE", "GE", "HE"},
-	"FC": {"DA", "DB", "DG", "DH", "EA", "EB", "EG", "EH", "AD", "BD", "GD", "HD", "AE", "BE", "GE", "HE"},
-
-	"CG": {"DH", "DA", "DB", "EH", "EA", "EB", "FH", "FA", "FB", "HD", "AD", "BD", "HE", "AE", "BE", "HF", "AF", "BF"},
-	"GC": {"DH", "DA", "DB", "EH", "EA", "EB", "FH", "FA", "FB", "HD", "AD", "BD", "HE", "AE", "BE", "HF", "AF", "BF"},
-
-	"CH": {"AD", "AE", "AF", "AG", "BD", "BE", "BF", "BG", "DA", "EA", "FA", "GA", "DB", "EB", "FB", "GB"},
-	"HC": {"AD", "AE", "AF", "AG", "BD", "BE", "BF", "BG", "DA", "EA", "FA", "GA", "DB", "EB", "FB", "GB"},
-
-	"DE": {}, "ED": {},
-
-	"DF": {"EA", "EB", "EC", "EG", "EH", "AE", "BE", "CE", "GE", "HE"},
-	"FD": {"EA", "EB", "EC", "EG", "EH", "AE", "BE", "CE", "GE", "HE"},
-
-	"DG": {"EA", "EB", "EC", "EH", "FA", "FB", "FC", "FH", "AE", "AF", "BE", "BF", "CE", "CF", "HE", "HF"},
-	"GD": {"EA", "EB", "EC", "EH", "FA", "FB", "FC", "FH", "AE", "AF", "BE", "BF", "CE", "CF", "HE", "HF"},
-
-	"DH": {"GA", "GB", "GC", "FA", "FB", "FC", "EA", "EB", "EC", "AG", "BG", "CG", "AF", "BF", "CF", "AE", "BE", "CE"},
-	"HD": {"GA", "GB", "GC", "FA", "FB", "FC", "EA", "EB", "EC", "AG", "BG", "CG", "AF", "BF", "CF", "AE", "BE", "CE"},
-
-	"EG": {"FA", "FB", "FC", "FD", "FH", "AF", "BF", "CF", "DF", "HF"},
-	"GE": {"FA", "FB", "FC", "FD", "FH", "AF", "BF", "CF", "DF", "HF"},
-
-	"EH": {"FA", "FB", "FC", "FD", "GA", "GB", "GC", "GD", "AF", "BF", "CF", "DF", "AG", "BG", "CG", "DG"},
-	"HE": {"FA", "FB", "FC", "FD", "GA", "GB", "GC", "GD", "AF", "BF", "CF", "DF", "AG", "BG", "CG", "DG"},
-
-	"FG": {}, "GF": {},
-
-	"FH": {"GA", "GB", "GC", "GD", "GE", "AG", "BG", "CG", "DG", "EG"},
-	"HF": {"GA", "GB", "GC", "GD", "GE", "AG", "BG", "CG", "DG", "EG"},
-}
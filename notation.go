@@ -0,0 +1,120 @@
+package go_tsuro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+	"github.com/quibbble/go-boardgame/pkg/bgn"
+)
+
+/*
+readable notation
+
+	B3 #17 R2
+
+B3 is the square in column B and row 3 counting from the top left, #17 the tile's ID in the
+catalogue, and R2 the number of times the tile is rotated right from its canonical orientation
+*/
+
+// FormatPlacement writes a tile placement in readable notation such as B3 #17 R2
+func FormatPlacement(placement PlaceTileActionDetails) (string, error) {
+	if placement.Row < 0 || placement.Column < 0 || placement.Column >= maxBoardSize {
+		return "", notationFailure(fmt.Errorf("row %d column %d cannot be written in notation", placement.Row, placement.Column))
+	}
+	tile, err := FormatTile(placement.Tile)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%c%d %s", 'A'+placement.Column, placement.Row+1, tile), nil
+}
+
+// ParsePlacement reads a tile placement written in readable notation such as B3 #17 R2
+func ParsePlacement(notation string) (PlaceTileActionDetails, error) {
+	fields := strings.Fields(notation)
+	if len(fields) != 3 || len(fields[0]) < 2 {
+		return PlaceTileActionDetails{}, notationFailure(fmt.Errorf("invalid placement notation %s", notation))
+	}
+	column := int(fields[0][0]) - 'A'
+	row, err := strconv.Atoi(fields[0][1:])
+	if err != nil || column < 0 || column >= maxBoardSize || row < 1 {
+		return PlaceTileActionDetails{}, notationFailure(fmt.Errorf("invalid square %s", fields[0]))
+	}
+	tile, err := ParseTile(fields[1] + " " + fields[2])
+	if err != nil {
+		return PlaceTileActionDetails{}, err
+	}
+	return PlaceTileActionDetails{
+		Row:    row - 1,
+		Column: column,
+		Tile:   tile,
+	}, nil
+}
+
+// FormatTile writes a tile in readable notation such as #17 R2
+func FormatTile(edges string) (string, error) {
+	id, rotation, err := TileID(edges)
+	if err != nil {
+		return "", notationFailure(err)
+	}
+	// prefer the rotation spelt the same so the tile reads back exactly
+	for r := rotation; r < 4; r += catalogue[id-1].Rotations {
+		if rotated, _ := TileEdges(id, r); rotated == edges {
+			rotation = r
+		}
+	}
+	return fmt.Sprintf("#%d R%d", id, rotation), nil
+}
+
+// ParseTile reads a tile written in readable notation such as #17 R2 returning its edges
+func ParseTile(notation string) (string, error) {
+	fields := strings.Fields(notation)
+	if len(fields) != 2 || !strings.HasPrefix(fields[0], "#") || !strings.HasPrefix(fields[1], "R") {
+		return "", notationFailure(fmt.Errorf("invalid tile notation %s", notation))
+	}
+	id, err := strconv.Atoi(fields[0][1:])
+	if err != nil {
+		return "", notationFailure(fmt.Errorf("invalid tile number %s", fields[0]))
+	}
+	rotation, err := strconv.Atoi(fields[1][1:])
+	if err != nil {
+		return "", notationFailure(fmt.Errorf("invalid rotation %s", fields[1]))
+	}
+	edges, err := TileEdges(id, rotation)
+	if err != nil {
+		return "", notationFailure(err)
+	}
+	return edges, nil
+}
+
+// GetReadableBGN returns the game's BGN with tiles and placements written in readable notation
+// which Builder.Load accepts in the same way as GetBGN
+func (t *Tsuro) GetReadableBGN() *bgn.Game {
+	game := t.GetBGN()
+	for idx, action := range t.publicActions() {
+		switch action.ActionType {
+		case ActionRotateTileRight, ActionRotateTileLeft:
+			var details RotateTileActionDetails
+			_ = mapstructure.Decode(action.MoreDetails, &details)
+			if readable, err := FormatTile(details.Tile); err == nil {
+				game.Actions[idx].Details = append(strings.Fields(readable), game.Actions[idx].Details[1:]...)
+			}
+		case ActionPlaceTile:
+			var details PlaceTileActionDetails
+			_ = mapstructure.Decode(action.MoreDetails, &details)
+			if readable, err := FormatPlacement(details); err == nil {
+				game.Actions[idx].Details = strings.Fields(readable)
+			}
+		}
+	}
+	return game
+}
+
+func notationFailure(err error) error {
+	return &bgerr.Error{
+		Err:    err,
+		Status: bgerr.StatusInvalidActionDetails,
+	}
+}
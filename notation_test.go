@@ -0,0 +1,75 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgn"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroNotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		placement PlaceTileActionDetails
+		notation  string
+		err       bool
+	}{
+		{name: "first tile", placement: PlaceTileActionDetails{Row: 0, Column: 0, Tile: "ABCDEFGH"}, notation: "A1 #1 R0"},
+		{name: "rotated tile", placement: PlaceTileActionDetails{Row: 2, Column: 1, Tile: "CBDAEFGH"}, notation: "B3 #2 R1"},
+		{name: "rotated symmetric tile", placement: PlaceTileActionDetails{Row: 5, Column: 5, Tile: "CDEFGHAB"}, notation: "F6 #1 R1"},
+		{name: "large board", placement: PlaceTileActionDetails{Row: 11, Column: 11, Tile: "ADBGCFEH"}, notation: "L12 #35 R0"},
+		{name: "invalid tile", placement: PlaceTileActionDetails{Row: 0, Column: 0, Tile: "AABBCCDD"}, err: true},
+		{name: "invalid square", placement: PlaceTileActionDetails{Row: -1, Column: 0, Tile: "ABCDEFGH"}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			notation, err := FormatPlacement(test.placement)
+			assert.Equal(t, test.err, err != nil)
+			if test.err {
+				return
+			}
+			assert.Equal(t, test.notation, notation)
+			placement, err := ParsePlacement(notation)
+			assert.NoError(t, err)
+			assert.Equal(t, test.placement, placement)
+		})
+	}
+	for _, notation := range []string{"", "B3", "B3 #17", "3B #17 R2", "B0 #17 R2", "Z3 #17 R2", "B3 #0 R2", "B3 #36 R2", "B3 #17 R4", "B3 17 2"} {
+		_, err := ParsePlacement(notation)
+		assert.Error(t, err, notation)
+	}
+
+	// games written in readable notation load the same as games in BGN
+	game := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, TsuroMoreOptions{Seed: 5, RandomTokens: true})
+	random := rand.New(rand.NewSource(5))
+	for i := 0; i < 6 && len(game.state.winners) == 0; i++ {
+		assert.NoError(t, game.Do(&bg.BoardGameAction{
+			Team:        game.state.turn,
+			ActionType:  ActionRotateTileLeft,
+			MoreDetails: RotateTileActionDetails{Tile: game.state.hands[game.state.turn].hand[0].Edges},
+		}))
+		placements, _ := game.Placements(game.state.turn)
+		placement := placements[random.Intn(len(placements))]
+		assert.NoError(t, game.Do(&bg.BoardGameAction{
+			Team:        game.state.turn,
+			ActionType:  ActionPlaceTile,
+			MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+		}))
+	}
+	readable := game.GetReadableBGN()
+	assert.Regexp(t, `^[A-L]\d+$`, readable.Actions[1].Details[0])
+	parsed, err := bgn.Parse(readable.String() + " {annotations are ignored}")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, readable.Actions, parsed.Actions)
+	loaded, err := (&Builder{}).Load(parsed)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, game.GetBGN(), loaded.GetBGN())
+}
@@ -0,0 +1,72 @@
+package go_tsuro
+
+// Observer is notified of changes to the game as they happen
+type Observer interface {
+	// OnTilePlaced is called when team places tile at row and column
+	OnTilePlaced(team string, row, column int, tile string)
+	// OnTokenMoved is called each time team's token travels across a tile
+	OnTokenMoved(team string, segment PathSegment)
+	// OnPlayerEliminated is called when team is knocked out for reason which is one of EventCollided, EventOffEdge, EventDaikaiju, EventLooped, or EventForfeit
+	OnPlayerEliminated(team, reason string)
+	// OnDragonChanged is called when the dragon tile passes to team or goes back to the box when team is empty
+	OnDragonChanged(team string)
+	// OnTurnChanged is called when it becomes team's turn
+	OnTurnChanged(team string)
+	// OnGameOver is called once the game has winners
+	OnGameOver(winners []string)
+}
+
+// BaseObserver ignores every change so observers can embed it and only implement the callbacks they need
+type BaseObserver struct{}
+
+func (BaseObserver) OnTilePlaced(team string, row, column int, tile string) {}
+func (BaseObserver) OnTokenMoved(team string, segment PathSegment)          {}
+func (BaseObserver) OnPlayerEliminated(team, reason string)                 {}
+func (BaseObserver) OnDragonChanged(team string)                            {}
+func (BaseObserver) OnTurnChanged(team string)                              {}
+func (BaseObserver) OnGameOver(winners []string)                            {}
+
+// AddObserver registers observer to be notified of every following change to the game
+func (t *Tsuro) AddObserver(observer Observer) {
+	t.state.observers = append(t.state.observers, observer)
+}
+
+// RemoveObserver stops notifying observer
+func (t *Tsuro) RemoveObserver(observer Observer) {
+	observers := make([]Observer, 0)
+	for _, o := range t.state.observers {
+		if o != observer {
+			observers = append(observers, o)
+		}
+	}
+	t.state.observers = observers
+}
+
+// record adds event to the events of the latest placement and notifies observers
+func (s *state) record(event *Event) {
+	s.events = append(s.events, event)
+	for _, observer := range s.observers {
+		switch event.Type {
+		case EventTilePlaced:
+			observer.OnTilePlaced(event.Team, event.Row, event.Column, event.Tile)
+		case EventTokenMoved:
+			observer.OnTokenMoved(event.Team, *event.Segment)
+		case EventCollided, EventOffEdge, EventDaikaiju, EventLooped, EventForfeit:
+			observer.OnPlayerEliminated(event.Team, event.Type)
+		case EventDragonPassed:
+			observer.OnDragonChanged(event.Team)
+		}
+	}
+}
+
+func (s *state) notifyTurn() {
+	for _, observer := range s.observers {
+		observer.OnTurnChanged(s.turn)
+	}
+}
+
+func (s *state) notifyGameOver() {
+	for _, observer := range s.observers {
+		observer.OnGameOver(s.winners)
+	}
+}
@@ -0,0 +1,147 @@
+package go_tsuro
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroEvents(t *testing.T) {
+	teams := []string{TeamA, TeamB, "TeamC", "TeamD"}
+	for _, variant := range []string{VariantClassic, VariantSeas} {
+		for seed := int64(0); seed < 10; seed++ {
+			tsuro := newTestTsuro(t, teams, TsuroMoreOptions{Seed: seed, Variant: variant, RandomTokens: true})
+			random := rand.New(rand.NewSource(seed))
+			for len(tsuro.state.winners) == 0 {
+				team := tsuro.state.turn
+				before := make(map[string]int)
+				for _, team := range teams {
+					before[team] = len(tsuro.state.paths[team])
+				}
+				placements, _ := tsuro.Placements(team)
+				placement := placements[random.Intn(len(placements))]
+				assert.NoError(t, tsuro.Do(&bg.BoardGameAction{
+					Team:        team,
+					ActionType:  ActionPlaceTile,
+					MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+				}))
+				events := tsuro.state.events
+				assert.Equal(t, &Event{Type: EventTilePlaced, Team: team, Row: placement.Row, Column: placement.Column, Tile: placement.Tile}, events[0])
+				// placements on the first row or column keep their coordinates in JSON
+				raw, err := json.Marshal(events[0])
+				assert.NoError(t, err)
+				var fields map[string]interface{}
+				assert.NoError(t, json.Unmarshal(raw, &fields))
+				assert.Equal(t, float64(placement.Row), fields["Row"])
+				assert.Equal(t, float64(placement.Column), fields["Column"])
+				moved := make(map[string][]*PathSegment)
+				for _, event := range events {
+					switch event.Type {
+					case EventTokenMoved:
+						moved[event.Team] = append(moved[event.Team], event.Segment)
+					case EventCollided, EventOffEdge, EventDaikaiju:
+						assert.False(t, tsuro.state.alive[event.Team])
+					case EventTileDrawn:
+						drawn, err := newTile(event.Tile)
+						assert.NoError(t, err)
+						assert.True(t, drawn.in(tsuro.state.hands[event.Team].hand) || !tsuro.state.alive[event.Team])
+					case EventDragonPassed:
+						assert.Equal(t, tsuro.state.dragon, event.Team)
+					}
+				}
+				// moves are reported in the order each token travelled
+				for _, team := range teams {
+					assert.ElementsMatch(t, tsuro.state.paths[team][before[team]:], moved[team])
+					for idx, segment := range moved[team] {
+						assert.Equal(t, tsuro.state.paths[team][before[team]+idx], segment)
+					}
+				}
+
+				// other teams do not see what was drawn into a hand that is not theirs
+				snapshot, _ := tsuro.GetSnapshot(TeamA)
+				for _, event := range snapshot.MoreData.(TsuroSnapshotData).Events {
+					if event.Type == EventTileDrawn && event.Team != TeamA {
+						assert.Empty(t, event.Tile)
+					}
+				}
+			}
+		}
+	}
+}
+
+type recordingObserver struct {
+	BaseObserver
+	placed     int
+	moved      map[string][]PathSegment
+	eliminated []string
+	turns      []string
+	dragon     string
+	winners    [][]string
+}
+
+func (o *recordingObserver) OnTilePlaced(team string, row, column int, tile string) {
+	o.placed++
+}
+
+func (o *recordingObserver) OnTokenMoved(team string, segment PathSegment) {
+	o.moved[team] = append(o.moved[team], segment)
+}
+
+func (o *recordingObserver) OnPlayerEliminated(team, reason string) {
+	o.eliminated = append(o.eliminated, team)
+}
+
+func (o *recordingObserver) OnDragonChanged(team string) {
+	o.dragon = team
+}
+
+func (o *recordingObserver) OnTurnChanged(team string) {
+	o.turns = append(o.turns, team)
+}
+
+func (o *recordingObserver) OnGameOver(winners []string) {
+	o.winners = append(o.winners, winners)
+}
+
+func Test_TsuroObserver(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		tsuro := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, TsuroMoreOptions{Seed: seed, RandomTokens: true})
+		observer := &recordingObserver{moved: make(map[string][]PathSegment)}
+		removed := &recordingObserver{moved: make(map[string][]PathSegment)}
+		tsuro.AddObserver(observer)
+		tsuro.AddObserver(removed)
+		tsuro.RemoveObserver(removed)
+
+		random := rand.New(rand.NewSource(seed))
+		placed := 0
+		for len(tsuro.state.winners) == 0 {
+			placements, _ := tsuro.Placements(tsuro.state.turn)
+			placement := placements[random.Intn(len(placements))]
+			assert.NoError(t, tsuro.Do(&bg.BoardGameAction{
+				Team:        tsuro.state.turn,
+				ActionType:  ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+			}))
+			placed++
+			if len(tsuro.state.winners) == 0 {
+				assert.Equal(t, tsuro.state.turn, observer.turns[len(observer.turns)-1])
+			}
+			assert.Equal(t, tsuro.state.dragon, observer.dragon)
+		}
+		assert.Equal(t, placed, observer.placed)
+		for _, team := range tsuro.state.teams {
+			path := make([]PathSegment, 0)
+			for _, segment := range tsuro.state.paths[team] {
+				path = append(path, *segment)
+			}
+			assert.Equal(t, path, append(make([]PathSegment, 0), observer.moved[team]...))
+			assert.Equal(t, !tsuro.state.alive[team], contains(observer.eliminated, team))
+		}
+		assert.Equal(t, [][]string{tsuro.state.winners}, observer.winners)
+		assert.Zero(t, removed.placed)
+		assert.Empty(t, removed.turns)
+	}
+}
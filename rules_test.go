@@ -0,0 +1,342 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// square is a tile at row and col written as the pairs of notches its paths join in any order, i.e. HACBDEFG joins H to A,
+// C to B, D to E, and F to G
+type square struct {
+	row, col int
+	pairs    string
+}
+
+// scenario sets up a position part way through a game, lets the team on turn place a tile, and describes what should happen
+type scenario struct {
+	name    string
+	teams   []string
+	options TsuroMoreOptions
+	board   []square            // tiles already on the board
+	tokens  map[string]*token   // tokens still on the board each having travelled the path on its tile ending at its notch
+	out     []string            // teams knocked out earlier in the game
+	hands   map[string][]string // pairs of each tile in hand replacing the dealt hands
+	deck    []string            // pairs of each tile left in the deck
+	dragon  string              // team holding the dragon tile
+	place   square              // tile the first team places
+
+	events  []string          // every event in order written as its type, team, and any other teams
+	alive   []string          // teams still in the game afterwards
+	winners []string          // winners once the game is over
+	moved   map[string]*token // where tokens end up
+	held    map[string]int    // number of tiles in each hand afterwards
+	turn    string            // team on turn afterwards
+}
+
+// joining returns the tile whose paths join the pairs of notches
+func joining(t *testing.T, pairs string) *tile {
+	shape, ok := shapesByEnds[endsOf(pairs)]
+	if !ok || len(pairs) != int(notchCount) {
+		t.Fatalf("%s does not join every notch", pairs)
+	}
+	tile, _ := newTile(shape.edges)
+	return tile
+}
+
+func (sc *scenario) setup(t *testing.T) *state {
+	options := sc.options
+	if options.Variant == "" {
+		options.Variant = VariantClassic
+	}
+	s, err := newState(sc.teams, rand.New(rand.NewSource(123)), &options, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sq := range sc.board {
+		s.board.board[sq.row][sq.col] = joining(t, sq.pairs)
+	}
+	for team, token := range sc.tokens {
+		copied := *token
+		s.tokens[team] = &copied
+		s.playedFirstTurn[team] = true
+		tile := s.board.board[token.Row][token.Col]
+		tile.travel(tile.destination(toNotch(token.Notch)), team)
+	}
+	for _, team := range sc.out {
+		s.alive[team] = false
+	}
+	for team, hand := range sc.hands {
+		s.hands[team].Clear()
+		for _, pairs := range hand {
+			s.hands[team].Add(joining(t, pairs))
+		}
+	}
+	s.deck.deck = make([]*tile, 0)
+	for _, pairs := range sc.deck {
+		s.deck.Add(joining(t, pairs))
+	}
+	s.dragon = sc.dragon
+	return s
+}
+
+func Test_StateRules(t *testing.T) {
+	straight := "AFBECHDG"
+	scenarios := []scenario{
+		{
+			name:  "every token knocked out by the same tile wins together",
+			teams: []string{TeamA, TeamB},
+			board: []square{{0, 0, straight}, {0, 2, straight}},
+			tokens: map[string]*token{
+				TeamA: newToken(0, 0, "C"),
+				TeamB: newToken(0, 2, "H"),
+			},
+			hands: map[string][]string{TeamA: {"HACBDEFG"}},
+			place: square{0, 1, "HACBDEFG"},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"OffEdge TeamA",
+				"OffEdge TeamB",
+			},
+			alive:   []string{},
+			winners: []string{TeamA, TeamB},
+			moved: map[string]*token{
+				TeamA: newToken(0, 1, "A"),
+				TeamB: newToken(0, 1, "B"),
+			},
+		},
+		{
+			name:  "teams knocked out earlier do not share a win with the teams knocked out together",
+			teams: []string{TeamA, TeamB, "TeamC"},
+			board: []square{{0, 0, straight}, {0, 2, straight}},
+			tokens: map[string]*token{
+				TeamA: newToken(0, 0, "C"),
+				TeamB: newToken(0, 2, "H"),
+			},
+			out:   []string{"TeamC"},
+			hands: map[string][]string{TeamA: {"HACBDEFG"}, "TeamC": {}},
+			place: square{0, 1, "HACBDEFG"},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"OffEdge TeamA",
+				"OffEdge TeamB",
+			},
+			alive:   []string{},
+			winners: []string{TeamA, TeamB},
+		},
+		{
+			name:  "tokens meeting head on both collide",
+			teams: []string{TeamA, TeamB, "TeamC"},
+			board: []square{{2, 0, straight}, {2, 2, straight}, {4, 4, straight}},
+			tokens: map[string]*token{
+				TeamA:   newToken(2, 0, "C"),
+				TeamB:   newToken(2, 2, "H"),
+				"TeamC": newToken(4, 4, "C"),
+			},
+			hands: map[string][]string{TeamA: {"HCABDEFG"}},
+			place: square{2, 1, "HCABDEFG"},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"Collided TeamA TeamB",
+				"Collided TeamB TeamA",
+			},
+			alive:   []string{"TeamC"},
+			winners: []string{"TeamC"},
+			moved: map[string]*token{
+				TeamA: newToken(2, 1, "C"),
+				TeamB: newToken(2, 2, "H"),
+			},
+		},
+		{
+			name:  "one tile moves the tokens of several other teams",
+			teams: []string{TeamA, TeamB, "TeamC"},
+			board: []square{{3, 2, straight}, {2, 3, straight}, {3, 4, straight}},
+			tokens: map[string]*token{
+				TeamA:   newToken(3, 2, "C"),
+				TeamB:   newToken(2, 3, "E"),
+				"TeamC": newToken(3, 4, "G"),
+			},
+			hands: map[string][]string{
+				TeamA:   {"HEBFDCAG", straight, straight},
+				TeamB:   {straight, straight, straight},
+				"TeamC": {straight, straight, straight},
+			},
+			deck:  []string{straight, straight},
+			place: square{3, 3, "HEBFDCAG"},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"TokenMoved TeamC",
+				"TokenMoved TeamC", // back through the tile TeamC came from
+				"TileDrawn TeamA",
+			},
+			alive:   []string{TeamA, TeamB, "TeamC"},
+			winners: []string{},
+			moved: map[string]*token{
+				TeamA:   newToken(3, 3, "E"),
+				TeamB:   newToken(3, 3, "F"),
+				"TeamC": newToken(3, 4, "C"),
+			},
+			held: map[string]int{TeamA: 3},
+			turn: TeamB,
+		},
+		{
+			name:  "dragon tile passes on when its holder is knocked out and the next team draws first",
+			teams: []string{TeamA, TeamB, "TeamC"},
+			board: []square{{0, 0, straight}, {0, 2, straight}, {4, 4, straight}},
+			tokens: map[string]*token{
+				TeamA:   newToken(0, 0, "C"),
+				TeamB:   newToken(0, 2, "H"),
+				"TeamC": newToken(4, 4, "C"),
+			},
+			hands: map[string][]string{
+				TeamA:   {"HECBADFG", straight},
+				TeamB:   {straight, straight},
+				"TeamC": {straight, straight},
+			},
+			dragon: TeamB,
+			place:  square{0, 1, "HECBADFG"},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"OffEdge TeamB",
+				"DragonPassed TeamC",
+				"TileDrawn TeamC",
+				"TileDrawn TeamA",
+				"DragonPassed", // back in the box as every hand needing a tile drew one
+			},
+			alive:   []string{TeamA, "TeamC"},
+			winners: []string{},
+			held:    map[string]int{TeamA: 2, TeamB: 0, "TeamC": 3},
+			turn:    "TeamC",
+		},
+		{
+			name:  "dragon tile passes on when its holder knocks itself out",
+			teams: []string{TeamA, TeamB, "TeamC"},
+			board: []square{{0, 0, straight}, {4, 4, straight}, {4, 0, straight}},
+			tokens: map[string]*token{
+				TeamA:   newToken(0, 0, "C"),
+				TeamB:   newToken(4, 4, "C"),
+				"TeamC": newToken(4, 0, "C"),
+			},
+			hands: map[string][]string{
+				TeamA:   {"HACBDEFG", straight},
+				TeamB:   {straight, straight},
+				"TeamC": {straight, straight, straight},
+			},
+			dragon: TeamA,
+			place:  square{0, 1, "HACBDEFG"},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"OffEdge TeamA",
+				"DragonPassed TeamB",
+				"TileDrawn TeamB",
+				"DragonPassed",
+			},
+			alive:   []string{TeamB, "TeamC"},
+			winners: []string{},
+			held:    map[string]int{TeamA: 0, TeamB: 3, "TeamC": 3},
+			turn:    TeamB,
+		},
+		{
+			name:    "final tile filling the board sends every token off the edge so the teams still in win",
+			teams:   []string{TeamA, TeamB},
+			options: TsuroMoreOptions{BoardSize: 4},
+			board:   fullBoard(4, square{1, 1, straight}),
+			tokens: map[string]*token{
+				TeamA: newToken(1, 0, "C"),
+				TeamB: newToken(1, 2, "G"),
+			},
+			hands: map[string][]string{TeamA: {straight}, TeamB: {}},
+			place: square{1, 1, straight},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"TokenMoved TeamA",
+				"OffEdge TeamA",
+				"OffEdge TeamB",
+			},
+			alive:   []string{},
+			winners: []string{TeamA, TeamB},
+			moved: map[string]*token{
+				TeamA: newToken(1, 3, "C"),
+				TeamB: newToken(1, 0, "G"),
+			},
+		},
+		{
+			name:    "final tile filling the board in LongestPath goes to the longest route",
+			teams:   []string{TeamA, TeamB},
+			options: TsuroMoreOptions{Variant: VariantLongestPath, BoardSize: 4},
+			board:   fullBoard(4, square{1, 1, straight}),
+			tokens: map[string]*token{
+				TeamA: newToken(1, 0, "C"),
+				TeamB: newToken(1, 2, "G"),
+			},
+			hands: map[string][]string{TeamA: {straight}, TeamB: {}},
+			place: square{1, 1, straight},
+			events: []string{
+				"TilePlaced TeamA",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"TokenMoved TeamA",
+				"TokenMoved TeamB",
+				"TokenMoved TeamA",
+				"OffEdge TeamA",
+				"OffEdge TeamB",
+			},
+			alive:   []string{},
+			winners: []string{TeamA},
+		},
+	}
+	for _, sc := range scenarios {
+		t.Run(sc.name, func(t *testing.T) {
+			s := sc.setup(t)
+			events, err := s.PlaceTile(s.turn, joining(t, sc.place.pairs).Edges, sc.place.row, sc.place.col)
+			if err != nil {
+				t.Fatal(err)
+			}
+			happened := make([]string, 0)
+			for _, event := range events {
+				happened = append(happened, strings.TrimSpace(strings.Join(append([]string{event.Type, event.Team}, event.Teams...), " ")))
+			}
+			assert.Equal(t, sc.events, happened)
+			assert.Equal(t, sc.alive, s.aliveTeams())
+			assert.Equal(t, sc.winners, s.winners)
+			for team, token := range sc.moved {
+				assert.Equal(t, *token, *s.tokens[team], team)
+			}
+			for team, held := range sc.held {
+				assert.Len(t, s.hands[team].hand, held, team)
+			}
+			if sc.turn != "" {
+				assert.Equal(t, sc.turn, s.turn)
+			}
+		})
+	}
+}
+
+// fullBoard fills every square of a size by size board with straight tiles except the empty square
+func fullBoard(size int, empty square) []square {
+	board := make([]square, 0)
+	for row := 0; row < size; row++ {
+		for col := 0; col < size; col++ {
+			if row != empty.row || col != empty.col {
+				board = append(board, square{row, col, "AFBECHDG"})
+			}
+		}
+	}
+	return board
+}
@@ -0,0 +1,61 @@
+package go_tsuro
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+)
+
+// Sample returns a copy of the game in which the tiles the view cannot see, those in the deck and in hidden hands,
+// are dealt again at random using seed which also replaces the seed for the rest of the copy so it can be played out
+// the copy's clock is stopped so no team runs out of time
+func (t *Tsuro) Sample(view View, seed int64) (*Tsuro, error) {
+	switch view.Mode {
+	case ViewPlayer:
+		if !contains(t.state.teams, view.Team) {
+			return nil, &bgerr.Error{
+				Err:    fmt.Errorf("%s not a valid team", view.Team),
+				Status: bgerr.StatusUnknownTeam,
+			}
+		}
+	case ViewSpectator, ViewOmniscient:
+	default:
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("cannot sample the %s view", view.Mode),
+			Status: bgerr.StatusInvalidOption,
+		}
+	}
+	game := t.Clone()
+	source := newSource(seed)
+	random := rand.New(source)
+	game.source = source
+	game.options.Seed = seed
+	game.script = nil
+	game.state.deck.random = random
+	game.state.deck.stacked = nil
+
+	// gather the unseen tiles then deal them back into the same places
+	unseen := append([]*tile{}, game.state.deck.deck...)
+	hidden := make([]*hand, 0)
+	dealt := make(map[*hand]bool)
+	for _, team := range game.state.teams {
+		h := game.state.hands[team]
+		if dealt[h] || t.sees(view.Mode, view.Team, team) {
+			continue
+		}
+		dealt[h] = true
+		hidden = append(hidden, h)
+		unseen = append(unseen, h.hand...)
+	}
+	random.Shuffle(len(unseen), func(i, j int) { unseen[i], unseen[j] = unseen[j], unseen[i] })
+	for _, h := range hidden {
+		size := len(h.hand)
+		h.hand = append([]*tile{}, unseen[:size]...)
+		unseen = unseen[size:]
+	}
+	game.state.deck.deck = unseen
+	game.SetClock(stoppedClock{now: time.Unix(0, 0)})
+	return game, nil
+}
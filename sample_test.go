@@ -0,0 +1,59 @@
+package go_tsuro
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroSample(t *testing.T) {
+	teams := []string{TeamA, TeamB, "TeamC"}
+	game := newTestTsuro(t, teams, TsuroMoreOptions{Seed: 8, RandomTokens: true})
+	edges := func(tiles []*tile) []string {
+		result := make([]string, 0)
+		for _, tile := range tiles {
+			result = append(result, tile.Edges)
+		}
+		return result
+	}
+	tests := []struct {
+		name  string
+		view  View
+		known []string // teams whose hands are kept
+		err   bool
+	}{
+		{name: "spectator", view: View{Mode: ViewSpectator}},
+		{name: "player", view: View{Mode: ViewPlayer, Team: TeamB}, known: []string{TeamB}},
+		{name: "omniscient", view: View{Mode: ViewOmniscient}, known: teams},
+		{name: "unknown team", view: View{Mode: ViewPlayer, Team: "TeamD"}, err: true},
+		{name: "replay", view: View{Mode: ViewReplay}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changed := false
+			for seed := int64(0); seed < 10; seed++ {
+				sample, err := game.Sample(test.view, seed)
+				assert.Equal(t, test.err, err != nil)
+				if test.err {
+					return
+				}
+				// the same tiles are dealt into hands of the same size
+				all, sampled := edges(game.state.deck.deck), edges(sample.state.deck.deck)
+				for _, team := range teams {
+					all = append(all, edges(game.state.hands[team].hand)...)
+					sampled = append(sampled, edges(sample.state.hands[team].hand)...)
+					assert.Len(t, sample.state.hands[team].hand, len(game.state.hands[team].hand))
+					if contains(test.known, team) {
+						assert.Equal(t, edges(game.state.hands[team].hand), edges(sample.state.hands[team].hand))
+					} else if !assert.ObjectsAreEqual(edges(game.state.hands[team].hand), edges(sample.state.hands[team].hand)) {
+						changed = true
+					}
+				}
+				assert.ElementsMatch(t, all, sampled)
+				assert.Equal(t, game.state.board, sample.state.board)
+				assert.Equal(t, game.state.tokens, sample.state.tokens)
+			}
+			assert.Equal(t, len(test.known) < len(teams), changed)
+		})
+	}
+}
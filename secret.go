@@ -0,0 +1,254 @@
+package go_tsuro
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	bg "github.com/quibbble/go-boardgame"
+)
+
+// script is the randomness of a secret seed game as recorded in its BGN which is replayed in place of the unknown seed
+type script struct {
+	Commitment string                       // hash of the secret seed
+	Tokens     map[string]*token            `json:",omitempty"` // tokens placed at random at the start
+	Daikaiju   []*daikaiju                  `json:",omitempty"` // squares the daikaiju start on
+	draws      []string                     // tiles in the order they were drawn
+	placed     []string                     // tiles placed in order which are the only tiles hidden draws cannot be
+	moves      []*MoveDaikaijuActionDetails // daikaiju moves in the order they were rolled
+}
+
+func (s *script) startingTokens() map[string]*token {
+	tokens := make(map[string]*token)
+	for team, token := range s.Tokens {
+		tokens[team] = newToken(token.Row, token.Col, token.Notch)
+	}
+	return tokens
+}
+
+func (s *script) startingDaikaiju() []*daikaiju {
+	var monsters []*daikaiju
+	for _, d := range s.Daikaiju {
+		copied := *d
+		monsters = append(monsters, &copied)
+	}
+	return monsters
+}
+
+// nonceSize is the number of random bytes hashed along with a secret seed so the seed cannot be found by trying every seed
+const nonceSize = 16
+
+// newNonce returns nonceSize random bytes written in hex
+func newNonce() (string, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := cryptorand.Read(nonce); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(nonce), nil
+}
+
+// unseeded returns a random source that has nothing to do with the game's seed
+func unseeded() *rand.Rand {
+	var seed [8]byte
+	_, _ = cryptorand.Read(seed[:])
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(seed[:]))))
+}
+
+// commit returns the hash of nonce and seed that is published in place of a secret seed so both can be checked once revealed
+// games recorded before the nonce was added have an empty nonce
+func commit(seed int64, nonce string) string {
+	sum := sha256.Sum256([]byte(nonce + strconv.FormatInt(seed, 10)))
+	return hex.EncodeToString(sum[:])
+}
+
+// commitment returns the hash of the game's seed and nonce
+func (t *Tsuro) commitment() string {
+	if t.script != nil {
+		return t.script.Commitment
+	}
+	return commit(t.options.Seed, t.nonce)
+}
+
+// replayScript returns the randomness needed to replay the game's actions which is nil unless the seed is unknown
+func (t *Tsuro) replayScript() *script {
+	if t.script == nil {
+		return nil
+	}
+	draws := append([]string{}, t.state.deck.drawn...)
+	for i, edges := range t.script.draws {
+		if edges == hiddenDraw && i < len(draws) {
+			// drawing in place of hidden draws again keeps the tiles for placed tiles as they were
+			draws[i] = hiddenDraw
+		}
+	}
+	return &script{
+		Commitment: t.script.Commitment,
+		Tokens:     t.script.Tokens,
+		Daikaiju:   t.script.Daikaiju,
+		draws:      draws,
+		placed:     t.script.placed,
+		moves:      recordedMoves(t.actions),
+	}
+}
+
+// recordedMoves returns the daikaiju moves found in actions in order
+func recordedMoves(actions []*bg.BoardGameAction) []*MoveDaikaijuActionDetails {
+	moves := make([]*MoveDaikaijuActionDetails, 0)
+	for _, action := range actions {
+		if action.ActionType == ActionMoveDaikaiju {
+			var details MoveDaikaijuActionDetails
+			_ = mapstructure.Decode(action.MoreDetails, &details)
+			moves = append(moves, &details)
+		}
+	}
+	return moves
+}
+
+// placedTiles returns the tiles placed by actions in order
+func placedTiles(actions []*bg.BoardGameAction) []string {
+	placed := make([]string, 0)
+	for _, action := range actions {
+		if action.ActionType == ActionPlaceTile {
+			var details PlaceTileActionDetails
+			_ = mapstructure.Decode(action.MoreDetails, &details)
+			placed = append(placed, details.Tile)
+		}
+	}
+	return placed
+}
+
+// starting returns the tokens placed at random and the daikaiju as they were at the start of the game
+func (t *Tsuro) starting() (map[string]*token, []*daikaiju) {
+	if t.script != nil {
+		return t.script.startingTokens(), t.script.startingDaikaiju()
+	}
+	state, err := newState(t.state.teams, rand.New(newSource(t.options.Seed)), t.options, nil)
+	if err != nil {
+		return nil, nil
+	}
+	return state.tokens, state.daikaiju
+}
+
+// hiddenDraw stands in the draws of a secret seed game in progress for a tile that has not been placed
+const hiddenDraw = "?"
+
+// publicDraws returns the tiles drawn so far with those that have not been placed hidden
+// the game is dealt again from the start as only then is it known which draws were of tiles that have since been placed
+func (t *Tsuro) publicDraws() []string {
+	draws := make([]string, len(t.state.deck.drawn))
+	for i := range draws {
+		draws[i] = hiddenDraw
+	}
+	game, err := t.replay(t.actions)
+	if err != nil {
+		return draws
+	}
+	for _, idx := range game.state.deck.placed {
+		draws[idx] = game.state.deck.drawn[idx]
+	}
+	return draws
+}
+
+// matchesDraws is true if drawn are the recorded draws where a hidden draw matches any tile
+func matchesDraws(drawn, draws []string) bool {
+	if len(drawn) != len(draws) {
+		return false
+	}
+	for i := range drawn {
+		if draws[i] != hiddenDraw && draws[i] != drawn[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// publicActions returns the actions shown in the BGN leaving out rotations of tiles still in a hidden hand
+func (t *Tsuro) publicActions() []*bg.BoardGameAction {
+	if !t.options.SecretSeed || len(t.state.winners) > 0 {
+		return t.actions
+	}
+	actions := make([]*bg.BoardGameAction, 0)
+	for _, action := range t.actions {
+		if action.ActionType != ActionRotateTileRight && action.ActionType != ActionRotateTileLeft {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// encodeTokensTag writes the token of each team in team order such as 0.2.A, 5.0.C
+func encodeTokensTag(teams []string, tokens map[string]*token) string {
+	notation := make([]string, 0)
+	for _, team := range teams {
+		if token, ok := tokens[team]; ok {
+			notation = append(notation, fmt.Sprintf("%d.%d.%s", token.Row, token.Col, token.Notch))
+		}
+	}
+	return strings.Join(notation, ", ")
+}
+
+func decodeTokensTag(teams []string, tag string, size int) (map[string]*token, error) {
+	notation := strings.Split(tag, ", ")
+	if len(notation) != len(teams) {
+		return nil, fmt.Errorf("invalid tokens tag")
+	}
+	tokens := make(map[string]*token)
+	for idx, n := range notation {
+		fields := strings.Split(n, ".")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid token %s", n)
+		}
+		row, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		col, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		token := newToken(row, col, fields[2])
+		if err := validToken(token, size); err != nil {
+			return nil, err
+		}
+		tokens[teams[idx]] = token
+	}
+	return tokens, nil
+}
+
+// encodeDaikaijuTag writes the square of each daikaiju such as 4.4, 1.7
+func encodeDaikaijuTag(monsters []*daikaiju) string {
+	notation := make([]string, 0)
+	for _, d := range monsters {
+		notation = append(notation, fmt.Sprintf("%d.%d", d.Row, d.Col))
+	}
+	return strings.Join(notation, ", ")
+}
+
+func decodeDaikaijuTag(tag string, size int) ([]*daikaiju, error) {
+	var monsters []*daikaiju
+	for _, n := range strings.Split(tag, ", ") {
+		fields := strings.Split(n, ".")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid daikaiju %s", n)
+		}
+		row, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		col, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		if row < 0 || row >= size || col < 0 || col >= size {
+			return nil, fmt.Errorf("invalid daikaiju %s", n)
+		}
+		monsters = append(monsters, &daikaiju{Row: row, Col: col})
+	}
+	return monsters, nil
+}
@@ -0,0 +1,150 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroSecretSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TsuroMoreOptions
+	}{
+		{name: "classic", options: TsuroMoreOptions{Variant: VariantClassic}},
+		{name: "seas", options: TsuroMoreOptions{Variant: VariantSeas}},
+		{name: "partners", options: TsuroMoreOptions{Variant: VariantPartners, Alliances: [][]string{{TeamA, "TeamC"}, {TeamB, "TeamD"}}}},
+		{name: "duplicates", options: TsuroMoreOptions{Variant: VariantClassic, Tiles: append(append([]string{}, tiles[:15]...), tiles[:15]...)}},
+	}
+	teams := []string{TeamA, TeamB, "TeamC", "TeamD"}
+	play := func(game *Tsuro, random *rand.Rand, placements int) {
+		for i := 0; i < placements && len(game.state.winners) == 0; i++ {
+			targets, _ := game.Placements(game.state.turn)
+			target := targets[random.Intn(len(targets))]
+			err := game.Do(&bg.BoardGameAction{
+				Team:        game.state.turn,
+				ActionType:  ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{Row: target.Row, Column: target.Column, Tile: target.Tile},
+			})
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+		}
+	}
+	builder := Builder{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := test.options
+			options.Seed = 11
+			options.RandomTokens = true
+			options.SecretSeed = true
+			game := newTestTsuro(t, teams, options)
+			play(game, rand.New(rand.NewSource(1)), 4)
+			inHand := game.state.hands[game.state.turn].hand
+			assert.NoError(t, game.Do(&bg.BoardGameAction{
+				Team:        game.state.turn,
+				ActionType:  ActionRotateTileRight,
+				MoreDetails: RotateTileActionDetails{Tile: inHand[0].Edges},
+			}))
+
+			// the seed, the nonce, and the draws of tiles still in hand are kept out of the record until the game is over
+			bgnGame := game.GetBGN()
+			for _, tag := range []string{"Seed", "SeedNonce"} {
+				_, ok := bgnGame.Tags[tag]
+				assert.False(t, ok)
+			}
+			assert.Equal(t, commit(11, game.nonce), bgnGame.Tags["SeedHash"])
+			assert.Len(t, game.nonce, 2*nonceSize)
+			assert.NotEqual(t, commit(11, ""), bgnGame.Tags["SeedHash"])
+			held, seen := 0, make(map[*hand]bool)
+			for _, h := range game.state.hands {
+				if !seen[h] { // partners share a hand
+					held += len(h.hand)
+					seen[h] = true
+				}
+			}
+			// tiles knocked out teams returned to the deck stay hidden too
+			assert.GreaterOrEqual(t, strings.Count(bgnGame.Tags["Draws"], hiddenDraw), held)
+			for _, action := range bgnGame.Actions {
+				assert.NotEqual(t, 'r', action.ActionKey)
+			}
+			assert.Equal(t, bgnGame, game.GetBGN())
+
+			// a game in progress replays the tiles that have been placed showing spectators the same game
+			random := rand.New(rand.NewSource(2))
+			for len(game.state.winners) == 0 {
+				bgnGame = game.GetBGN()
+				loaded, err := builder.Load(bgnGame)
+				if err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+				expected, _ := game.GetView(View{Mode: ViewSpectator})
+				actual, _ := loaded.(*Tsuro).GetView(View{Mode: ViewSpectator})
+				assert.Equal(t, expected, actual)
+				assert.Equal(t, bgnGame, loaded.GetBGN())
+				play(game, random, 1)
+			}
+
+			// the seed and nonce are revealed once the game is over and must match the hash
+			bgnGame = game.GetBGN()
+			assert.Equal(t, "11", bgnGame.Tags["Seed"])
+			assert.Equal(t, game.nonce, bgnGame.Tags["SeedNonce"])
+			assert.Equal(t, strings.Join(game.state.deck.drawn, ", "), bgnGame.Tags["Draws"])
+			_, err := builder.Load(bgnGame)
+			assert.NoError(t, err)
+			for tag, value := range map[string]string{"Seed": "12", "SeedNonce": strings.Repeat("0", 2*nonceSize)} {
+				tampered := game.GetBGN()
+				tampered.Tags[tag] = value
+				_, err = builder.Load(tampered)
+				assert.Error(t, err)
+			}
+
+			// a record without the seed replays the recorded draws in its place
+			bgnGame = game.GetBGN()
+			delete(bgnGame.Tags, "Seed")
+			delete(bgnGame.Tags, "SeedNonce")
+			loaded, err := builder.Load(bgnGame)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			expected, _ := game.GetSnapshot(TeamA)
+			actual, _ := loaded.GetSnapshot(TeamA)
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, bgnGame, loaded.GetBGN())
+
+			// undoing replays the recorded draws
+			assert.NoError(t, game.Undo(1))
+			assert.NoError(t, loaded.(*Tsuro).Undo(1))
+			expected, _ = game.GetSnapshot(TeamA)
+			actual, _ = loaded.GetSnapshot(TeamA)
+			assert.Equal(t, expected, actual)
+
+			// the recorded randomness and the nonce are saved with the game
+			for _, marshal := range []func() ([]byte, error){loaded.(*Tsuro).MarshalState, loaded.(*Tsuro).MarshalStateBinary, game.MarshalState, game.MarshalStateBinary} {
+				data, err := marshal()
+				assert.NoError(t, err)
+				restored, err := UnmarshalState(data)
+				if err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+				assert.Equal(t, game.commitment(), restored.commitment())
+				assert.NoError(t, restored.Undo(1))
+			}
+
+			// draws that could not have happened are caught
+			tampered := bgnGame
+			draws := strings.Split(tampered.Tags["Draws"], ", ")
+			draws[1] = draws[0]
+			tampered.Tags["Draws"] = strings.Join(draws, ", ")
+			_, err = builder.Load(tampered)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,981 @@
+package go_tsuro
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+	"github.com/quibbble/go-boardgame/pkg/bgn"
+)
+
+// savedVersion is raised whenever the saved layout changes so older saves are migrated rather than misread
+// 1 holds the board, deck, hands, tokens, and actions
+// 2 adds token paths, placement events, timers, and the draws and script of a secret seed
+// 3 adds the tile set
+// 4 adds the nonce hashed with a secret seed
+// 5 adds when each action was taken along with the timers just after it
+const savedVersion = 5
+
+// binaryMagic starts every state saved in the binary form followed by the version
+var binaryMagic = []byte("TSR")
+
+// savedGame is the complete game in a form that can be written to disk and restored without replaying any actions
+type savedGame struct {
+	Version         int
+	Options         TsuroMoreOptions
+	Teams           []string
+	Drawn           uint64 // values drawn from the seeded random source so later shuffles and rolls match the game
+	Turn            string
+	Winners         []string `json:",omitempty"`
+	Board           [][]*tile
+	Deck            []*tile        // in draw order with the next tile drawn last
+	Hands           [][]*tile      // distinct hands as teams may share one
+	HandOf          map[string]int // index into Hands for each team
+	Tokens          map[string]*token
+	Dragon          string `json:",omitempty"`
+	PlayedFirstTurn map[string]bool
+	Alive           map[string]bool
+	Points          map[string]int             `json:",omitempty"`
+	Daikaiju        []*daikaiju                `json:",omitempty"`
+	Destroyed       []*tile                    `json:",omitempty"`
+	DaikaijuMove    *MoveDaikaijuActionDetails `json:",omitempty"`
+	Paths           map[string][]*PathSegment
+	Events          []*Event                 `json:",omitempty"`
+	TurnElapsed     time.Duration            `json:",omitempty"` // time taken so far in the current turn
+	TimeLeft        map[string]time.Duration `json:",omitempty"` // game time left for each team
+	Actions         []string                 // actions taken so far in notation
+	Draws           []string                 // tiles drawn so far in order
+	Script          *script                  `json:",omitempty"` // randomness replayed in place of an unknown secret seed
+	Nonce           string                   `json:",omitempty"` // hashed with a secret seed
+	Readings        []*reading               `json:",omitempty"` // timers just after each action when the game is timed
+}
+
+// MarshalState saves the complete game as JSON
+func (t *Tsuro) MarshalState() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false) // keep actions readable as they contain &
+	if err := encoder.Encode(t.save()); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// MarshalStateBinary saves the complete game in a compact binary form
+func (t *Tsuro) MarshalStateBinary() ([]byte, error) {
+	saved := t.save()
+	e := &encoder{buf: append([]byte{}, binaryMagic...), teams: saved.Teams}
+	e.uint(savedVersion)
+	e.strings(saved.Teams)
+	e.int(saved.Options.Seed)
+	e.string(saved.Options.Variant)
+	e.bool(saved.Options.RandomTokens)
+	e.bool(saved.Options.CollapseRotations)
+	e.uint(uint64(saved.Options.BoardSize))
+	e.bool(saved.Options.NoSuicide)
+	e.int(int64(saved.Options.TurnTime))
+	e.int(int64(saved.Options.GameTime))
+	e.string(saved.Options.TimeoutMove)
+	e.uint(uint64(len(saved.Options.Alliances)))
+	for _, alliance := range saved.Options.Alliances {
+		e.uint(uint64(len(alliance)))
+		for _, team := range alliance {
+			e.team(team)
+		}
+	}
+	e.uint(saved.Drawn)
+	e.team(saved.Turn)
+	e.strings(saved.Winners)
+	for _, row := range saved.Board {
+		for _, tile := range row {
+			e.bool(tile != nil)
+			if tile != nil {
+				e.tile(tile)
+			}
+		}
+	}
+	e.tiles(saved.Deck)
+	e.uint(uint64(len(saved.Hands)))
+	for _, hand := range saved.Hands {
+		e.tiles(hand)
+	}
+	for _, team := range saved.Teams {
+		e.uint(uint64(saved.HandOf[team]))
+		token, ok := saved.Tokens[team]
+		e.bool(ok)
+		if ok {
+			e.uint(uint64(token.Row))
+			e.uint(uint64(token.Col))
+			e.string(token.Notch)
+		}
+		e.bool(saved.PlayedFirstTurn[team])
+		e.bool(saved.Alive[team])
+		points, ok := saved.Points[team]
+		e.bool(ok)
+		if ok {
+			e.int(int64(points))
+		}
+	}
+	e.team(saved.Dragon)
+	e.uint(uint64(len(saved.Daikaiju)))
+	for _, d := range saved.Daikaiju {
+		e.uint(uint64(d.Row))
+		e.uint(uint64(d.Col))
+	}
+	e.tiles(saved.Destroyed)
+	e.bool(saved.DaikaijuMove != nil)
+	if saved.DaikaijuMove != nil {
+		e.uint(uint64(saved.DaikaijuMove.Roll))
+		e.uint(uint64(len(saved.DaikaijuMove.Directions)))
+		for _, direction := range saved.DaikaijuMove.Directions {
+			e.uint(uint64(direction))
+		}
+	}
+	for _, team := range saved.Teams {
+		e.uint(uint64(len(saved.Paths[team])))
+		for _, segment := range saved.Paths[team] {
+			e.uint(uint64(segment.Row))
+			e.uint(uint64(segment.Column))
+			e.string(segment.Entry)
+			e.string(segment.Exit)
+		}
+	}
+	e.uint(uint64(len(saved.Events)))
+	for _, event := range saved.Events {
+		e.string(event.Type)
+		e.team(event.Team)
+		e.uint(uint64(event.Row))
+		e.uint(uint64(event.Column))
+		e.string(event.Tile)
+		e.bool(event.Segment != nil)
+		if event.Segment != nil {
+			e.uint(uint64(event.Segment.Row))
+			e.uint(uint64(event.Segment.Column))
+			e.string(event.Segment.Entry)
+			e.string(event.Segment.Exit)
+		}
+		e.bool(event.Teams != nil)
+		if event.Teams != nil {
+			e.uint(uint64(len(event.Teams)))
+			for _, team := range event.Teams {
+				e.team(team)
+			}
+		}
+	}
+	e.int(int64(saved.TurnElapsed))
+	e.bool(saved.TimeLeft != nil)
+	if saved.TimeLeft != nil {
+		for _, team := range saved.Teams {
+			e.int(int64(saved.TimeLeft[team]))
+		}
+	}
+	e.strings(saved.Actions)
+	e.bool(saved.Options.SecretSeed)
+	e.strings(saved.Draws)
+	e.bool(saved.Script != nil)
+	if saved.Script != nil {
+		e.string(saved.Script.Commitment)
+		for _, team := range saved.Teams {
+			token, ok := saved.Script.Tokens[team]
+			e.bool(ok)
+			if ok {
+				e.uint(uint64(token.Row))
+				e.uint(uint64(token.Col))
+				e.string(token.Notch)
+			}
+		}
+		e.uint(uint64(len(saved.Script.Daikaiju)))
+		for _, d := range saved.Script.Daikaiju {
+			e.uint(uint64(d.Row))
+			e.uint(uint64(d.Col))
+		}
+	}
+	e.strings(saved.Options.Tiles)
+	e.string(saved.Nonce)
+	e.uint(uint64(len(saved.Readings)))
+	for _, r := range saved.Readings {
+		e.bool(r != nil)
+		if r != nil {
+			e.int(r.At.UnixNano())
+			e.int(r.Started.UnixNano())
+			e.bool(r.Left != nil)
+			if r.Left != nil {
+				for _, team := range saved.Teams {
+					e.int(int64(r.Left[team]))
+				}
+			}
+		}
+	}
+	return e.buf, nil
+}
+
+// UnmarshalState restores a game saved by MarshalState or MarshalStateBinary
+func UnmarshalState(data []byte) (*Tsuro, error) {
+	var saved *savedGame
+	var err error
+	if bytes.HasPrefix(data, binaryMagic) {
+		saved, err = decodeBinary(data[len(binaryMagic):])
+	} else {
+		saved = &savedGame{}
+		err = json.Unmarshal(data, saved)
+	}
+	if err != nil {
+		return nil, restoreFailure(err)
+	}
+	t, err := restore(saved)
+	if err != nil {
+		return nil, restoreFailure(err)
+	}
+	return t, nil
+}
+
+func (t *Tsuro) save() *savedGame {
+	s := t.state
+	board := make([][]*tile, s.board.rows)
+	for row := range s.board.board {
+		board[row] = make([]*tile, s.board.columns)
+		for col, tile := range s.board.board[row] {
+			if tile != nil {
+				board[row][col] = tile.copy()
+			}
+		}
+	}
+	hands := make([][]*tile, 0)
+	handOf := make(map[string]int)
+	seen := make(map[*hand]int)
+	for _, team := range s.teams {
+		h := s.hands[team]
+		if _, ok := seen[h]; !ok {
+			seen[h] = len(hands)
+			hands = append(hands, copyTiles(h.hand))
+		}
+		handOf[team] = seen[h]
+	}
+	tokens := make(map[string]*token)
+	for team, token := range s.tokens {
+		tokens[team] = newToken(token.Row, token.Col, token.Notch)
+	}
+	var daikaiju []*daikaiju
+	for _, d := range s.daikaiju {
+		copied := *d
+		daikaiju = append(daikaiju, &copied)
+	}
+	actions := make([]string, 0)
+	for _, action := range t.actions {
+		notation := encodeActionBGN(s.teams, action)
+		actions = append(actions, notation.String())
+	}
+	options := *t.options
+	var timeLeft map[string]time.Duration
+	if t.options.GameTime > 0 {
+		timeLeft = make(map[string]time.Duration)
+		for team, left := range t.timer.left {
+			timeLeft[team] = left
+		}
+	}
+	return &savedGame{
+		Version:         savedVersion,
+		Options:         options,
+		Teams:           append([]string{}, s.teams...),
+		Drawn:           t.source.drawn,
+		Turn:            s.turn,
+		Winners:         append([]string{}, s.winners...),
+		Board:           board,
+		Deck:            copyTiles(s.deck.deck),
+		Hands:           hands,
+		HandOf:          handOf,
+		Tokens:          tokens,
+		Dragon:          s.dragon,
+		PlayedFirstTurn: copyBools(s.playedFirstTurn),
+		Alive:           copyBools(s.alive),
+		Points:          copyInts(s.points),
+		Daikaiju:        daikaiju,
+		Destroyed:       copyTiles(s.destroyed),
+		DaikaijuMove:    s.daikaijuMove,
+		Paths:           copyPaths(s.paths),
+		Events:          copyEvents(s.events),
+		TurnElapsed:     t.elapsed(),
+		TimeLeft:        timeLeft,
+		Actions:         actions,
+		Draws:           append([]string{}, s.deck.drawn...),
+		Script:          t.script,
+		Nonce:           t.nonce,
+		Readings:        append([]*reading{}, t.timer.readings...),
+	}
+}
+
+// restore rebuilds a game from its saved form checking that the saved form describes a valid game
+func restore(saved *savedGame) (*Tsuro, error) {
+	if saved.Version < 1 || saved.Version > savedVersion {
+		return nil, fmt.Errorf("unsupported saved state version %d", saved.Version)
+	}
+	teams := saved.Teams
+	if len(teams) < minTeams || len(teams) > maxTeams || duplicates(teams) {
+		return nil, fmt.Errorf("invalid teams %v", teams)
+	}
+	options := saved.Options
+	if saved.Version < 2 {
+		options.TimeoutMove = TimeoutRandom // version 1 came before timers
+	}
+	if !contains(variants, options.Variant) {
+		return nil, fmt.Errorf("invalid variant %s", options.Variant)
+	}
+	if !contains(timeoutMoves, options.TimeoutMove) || options.TurnTime < 0 || options.GameTime < 0 {
+		return nil, fmt.Errorf("invalid timer options")
+	}
+	size := options.BoardSize
+	if size < minBoardSize || size > maxBoardSize || len(saved.Board) != size {
+		return nil, fmt.Errorf("invalid board size %d", size)
+	}
+	if options.Variant == VariantPartners {
+		if err := validAlliances(teams, options.Alliances); err != nil {
+			return nil, err
+		}
+	}
+	if saved.Turn != "" && !contains(teams, saved.Turn) {
+		return nil, fmt.Errorf("turn belongs to unknown team %s", saved.Turn)
+	}
+	if saved.Dragon != "" && !contains(teams, saved.Dragon) {
+		return nil, fmt.Errorf("dragon belongs to unknown team %s", saved.Dragon)
+	}
+	count := 0
+	b := newBoard(size, size)
+	for row := range saved.Board {
+		if len(saved.Board[row]) != size {
+			return nil, fmt.Errorf("invalid board size %d", size)
+		}
+		for col, tile := range saved.Board[row] {
+			if tile == nil {
+				continue
+			}
+			if err := validTile(tile); err != nil {
+				return nil, err
+			}
+			b.board[row][col] = tile.copy()
+			count++
+		}
+	}
+	for _, list := range append([][]*tile{saved.Deck, saved.Destroyed}, saved.Hands...) {
+		for _, tile := range list {
+			if err := validTile(tile); err != nil {
+				return nil, err
+			}
+		}
+		count += len(list)
+	}
+	set := deckTiles(&options)
+	for _, edges := range set {
+		if _, err := newTile(edges); err != nil {
+			return nil, err
+		}
+	}
+	if count != len(set) {
+		return nil, fmt.Errorf("found %d tiles instead of %d", count, len(set))
+	}
+	for _, edges := range saved.Draws {
+		if _, err := newTile(edges); err != nil {
+			return nil, err
+		}
+	}
+	if saved.Script != nil {
+		for team, token := range saved.Script.Tokens {
+			if !contains(teams, team) || validToken(token, size) != nil {
+				return nil, fmt.Errorf("invalid starting token for %s", team)
+			}
+		}
+		for _, d := range saved.Script.Daikaiju {
+			if d == nil || d.Row < 0 || d.Row >= size || d.Col < 0 || d.Col >= size {
+				return nil, fmt.Errorf("invalid starting daikaiju")
+			}
+		}
+	}
+	handList := make([]*hand, 0)
+	for _, list := range saved.Hands {
+		h := newHand()
+		h.Add(copyTiles(list)...)
+		handList = append(handList, h)
+	}
+	hands := make(map[string]*hand)
+	for _, team := range teams {
+		idx, ok := saved.HandOf[team]
+		if !ok || idx < 0 || idx >= len(handList) {
+			return nil, fmt.Errorf("missing hand for %s", team)
+		}
+		hands[team] = handList[idx]
+	}
+	for team, path := range saved.Paths {
+		if !contains(teams, team) {
+			return nil, fmt.Errorf("path belongs to unknown team %s", team)
+		}
+		for _, segment := range path {
+			if segment == nil || segment.Row < 0 || segment.Row >= size || segment.Column < 0 || segment.Column >= size {
+				return nil, fmt.Errorf("invalid path for %s", team)
+			}
+		}
+	}
+	for _, event := range saved.Events {
+		if event == nil || (event.Team != "" && !contains(teams, event.Team)) {
+			return nil, fmt.Errorf("invalid event")
+		}
+	}
+	tokens := make(map[string]*token)
+	for team, token := range saved.Tokens {
+		if !contains(teams, team) {
+			return nil, fmt.Errorf("token belongs to unknown team %s", team)
+		}
+		if err := validToken(token, size); err != nil {
+			return nil, fmt.Errorf("invalid token for %s", team)
+		}
+		tokens[team] = newToken(token.Row, token.Col, token.Notch)
+	}
+	var daikaiju []*daikaiju
+	for _, d := range saved.Daikaiju {
+		if d == nil || d.Row < 0 || d.Row >= size || d.Col < 0 || d.Col >= size {
+			return nil, fmt.Errorf("invalid daikaiju")
+		}
+		copied := *d
+		daikaiju = append(daikaiju, &copied)
+	}
+	// the deck is restored as saved and the random source only moves on to where later shuffles and rolls continue
+	if saved.Drawn > maxDrawn(len(set), len(teams), len(saved.Actions)) {
+		return nil, fmt.Errorf("%d values drawn is more than the game could draw", saved.Drawn)
+	}
+	source := newSource(options.Seed)
+	source.advance(saved.Drawn)
+	random := rand.New(source)
+	points := copyInts(saved.Points)
+	if points == nil {
+		points = make(map[string]int)
+	}
+	s := &state{
+		turn:            saved.Turn,
+		teams:           append([]string{}, teams...),
+		winners:         append([]string{}, saved.Winners...),
+		board:           b,
+		deck:            &deck{deck: copyTiles(saved.Deck), random: random, drawn: append([]string{}, saved.Draws...)},
+		tokens:          tokens,
+		hands:           hands,
+		dragon:          saved.Dragon,
+		playedFirstTurn: copyBools(saved.PlayedFirstTurn),
+		alive:           copyBools(saved.Alive),
+		variant:         options.Variant,
+		points:          points,
+		noSuicide:       options.NoSuicide,
+		alliances:       options.Alliances,
+		daikaiju:        daikaiju,
+		destroyed:       copyTiles(saved.Destroyed),
+		daikaijuMove:    saved.DaikaijuMove,
+		paths:           copyPaths(saved.Paths),
+		events:          copyEvents(saved.Events),
+	}
+	actions := make([]*bg.BoardGameAction, 0)
+	for _, notation := range saved.Actions {
+		parsed, err := parseActionBGN(notation)
+		if err != nil {
+			return nil, err
+		}
+		action, err := decodeActionBGN(teams, parsed)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	t := &Tsuro{
+		state:   s,
+		actions: actions,
+		options: &options,
+		source:  source,
+		script:  saved.Script,
+		nonce:   saved.Nonce,
+	}
+	t.SetClock(systemClock{})
+	t.timer.started = t.timer.started.Add(-saved.TurnElapsed)
+	for team, left := range saved.TimeLeft {
+		t.timer.left[team] = left
+	}
+	if len(saved.Readings) > len(actions) {
+		return nil, fmt.Errorf("found %d readings for %d actions", len(saved.Readings), len(actions))
+	}
+	for _, r := range saved.Readings {
+		if r == nil {
+			continue
+		}
+		for team := range r.Left {
+			if !contains(teams, team) {
+				return nil, fmt.Errorf("reading belongs to unknown team %s", team)
+			}
+		}
+	}
+	t.timer.readings = append([]*reading{}, saved.Readings...)
+	if options.TurnTime > 0 || options.GameTime > 0 {
+		// actions saved before their times were recorded have unknown readings
+		for len(t.timer.readings) < len(actions) {
+			t.timer.readings = append(t.timer.readings, nil)
+		}
+	}
+	if saved.Version < 2 {
+		// version 1 did not save paths, events, or draws so rebuild them by replaying the actions
+		game, err := t.replay(t.actions)
+		if err != nil {
+			return nil, err
+		}
+		s.paths, s.events, s.deck.drawn = game.state.paths, game.state.events, game.state.deck.drawn
+	}
+	return t, nil
+}
+
+// maxDrawn is the most values a game with this many tiles, teams, and actions can draw from its random source
+// setup shuffles the deck and places tokens and daikaiju then each action shuffles at most every hand back into the deck
+// and rolls the dice for each daikaiju with the total doubled as Intn now and then draws again
+func maxDrawn(tiles, teams, actions int) uint64 {
+	setup := tiles + 2*teams + 4*seasDaikaiju
+	action := teams*tiles + 2 + seasDaikaiju
+	return 2 * uint64(setup+actions*action)
+}
+
+// parseActionBGN reads a single action written in notation such as 0p&2.3.ABCDEFGH
+func parseActionBGN(notation string) (bgn.Action, error) {
+	idx := 0
+	for idx < len(notation) && notation[idx] >= '0' && notation[idx] <= '9' {
+		idx++
+	}
+	if idx == 0 || idx == len(notation) {
+		return bgn.Action{}, fmt.Errorf("invalid action notation %s", notation)
+	}
+	team, err := strconv.Atoi(notation[:idx])
+	if err != nil {
+		return bgn.Action{}, err
+	}
+	action := bgn.Action{
+		TeamIndex: team,
+		ActionKey: rune(notation[idx]),
+	}
+	if rest := notation[idx+1:]; rest != "" {
+		if !strings.HasPrefix(rest, "&") {
+			return bgn.Action{}, fmt.Errorf("invalid action notation %s", notation)
+		}
+		action.Details = strings.Split(rest[1:], ".")
+	}
+	return action, nil
+}
+
+func decodeBinary(data []byte) (*savedGame, error) {
+	d := &decoder{buf: data}
+	saved := &savedGame{Version: int(d.uint())}
+	if saved.Version < 1 || saved.Version > savedVersion {
+		return nil, fmt.Errorf("unsupported saved state version %d", saved.Version)
+	}
+	saved.Teams = d.strings()
+	d.teams = saved.Teams
+	saved.Options.Seed = d.int()
+	saved.Options.Variant = d.string()
+	saved.Options.RandomTokens = d.bool()
+	saved.Options.CollapseRotations = d.bool()
+	saved.Options.BoardSize = int(d.uint())
+	saved.Options.NoSuicide = d.bool()
+	if saved.Version >= 2 {
+		saved.Options.TurnTime = time.Duration(d.int())
+		saved.Options.GameTime = time.Duration(d.int())
+		saved.Options.TimeoutMove = d.string()
+	}
+	for i, alliances := 0, d.count(); i < alliances; i++ {
+		alliance := make([]string, 0)
+		for j, members := 0, d.count(); j < members; j++ {
+			alliance = append(alliance, d.team())
+		}
+		saved.Options.Alliances = append(saved.Options.Alliances, alliance)
+	}
+	saved.Drawn = d.uint()
+	saved.Turn = d.team()
+	saved.Winners = d.strings()
+	size := saved.Options.BoardSize
+	if size > maxBoardSize {
+		return nil, fmt.Errorf("invalid board size %d", size)
+	}
+	saved.Board = make([][]*tile, size)
+	for row := 0; row < size && d.err == nil; row++ {
+		saved.Board[row] = make([]*tile, size)
+		for col := 0; col < size; col++ {
+			if d.bool() {
+				saved.Board[row][col] = d.tile()
+			}
+		}
+	}
+	saved.Deck = d.tiles()
+	for i, hands := 0, d.count(); i < hands; i++ {
+		saved.Hands = append(saved.Hands, d.tiles())
+	}
+	saved.HandOf = make(map[string]int)
+	saved.Tokens = make(map[string]*token)
+	saved.PlayedFirstTurn = make(map[string]bool)
+	saved.Alive = make(map[string]bool)
+	saved.Points = make(map[string]int)
+	for _, team := range saved.Teams {
+		saved.HandOf[team] = int(d.uint())
+		if d.bool() {
+			row, col := int(d.uint()), int(d.uint())
+			saved.Tokens[team] = newToken(row, col, d.string())
+		}
+		saved.PlayedFirstTurn[team] = d.bool()
+		saved.Alive[team] = d.bool()
+		if d.bool() {
+			saved.Points[team] = int(d.int())
+		}
+	}
+	saved.Dragon = d.team()
+	for i, count := 0, d.count(); i < count; i++ {
+		saved.Daikaiju = append(saved.Daikaiju, &daikaiju{Row: int(d.uint()), Col: int(d.uint())})
+	}
+	saved.Destroyed = d.tiles()
+	if d.bool() {
+		saved.DaikaijuMove = &MoveDaikaijuActionDetails{Roll: int(d.uint()), Directions: make([]int, 0)}
+		for i, count := 0, d.count(); i < count; i++ {
+			saved.DaikaijuMove.Directions = append(saved.DaikaijuMove.Directions, int(d.uint()))
+		}
+	}
+	if saved.Version < 2 {
+		saved.Actions = d.strings()
+		return saved, d.finish()
+	}
+	saved.Paths = make(map[string][]*PathSegment)
+	for _, team := range saved.Teams {
+		for i, count := 0, d.count(); i < count; i++ {
+			row, col := int(d.uint()), int(d.uint())
+			entry, exit := d.string(), d.string()
+			saved.Paths[team] = append(saved.Paths[team], &PathSegment{Row: row, Column: col, Entry: entry, Exit: exit})
+		}
+	}
+	for i, count := 0, d.count(); i < count; i++ {
+		event := &Event{Type: d.string(), Team: d.team(), Row: int(d.uint()), Column: int(d.uint()), Tile: d.string()}
+		if d.bool() {
+			row, col := int(d.uint()), int(d.uint())
+			entry, exit := d.string(), d.string()
+			event.Segment = &PathSegment{Row: row, Column: col, Entry: entry, Exit: exit}
+		}
+		if d.bool() {
+			event.Teams = make([]string, 0)
+			for j, teams := 0, d.count(); j < teams; j++ {
+				event.Teams = append(event.Teams, d.team())
+			}
+		}
+		saved.Events = append(saved.Events, event)
+	}
+	saved.TurnElapsed = time.Duration(d.int())
+	if d.bool() {
+		saved.TimeLeft = make(map[string]time.Duration)
+		for _, team := range saved.Teams {
+			saved.TimeLeft[team] = time.Duration(d.int())
+		}
+	}
+	saved.Actions = d.strings()
+	saved.Options.SecretSeed = d.bool()
+	saved.Draws = d.strings()
+	if d.bool() {
+		saved.Script = &script{Commitment: d.string()}
+		for _, team := range saved.Teams {
+			if d.bool() {
+				if saved.Script.Tokens == nil {
+					saved.Script.Tokens = make(map[string]*token)
+				}
+				row, col := int(d.uint()), int(d.uint())
+				saved.Script.Tokens[team] = newToken(row, col, d.string())
+			}
+		}
+		for i, count := 0, d.count(); i < count; i++ {
+			saved.Script.Daikaiju = append(saved.Script.Daikaiju, &daikaiju{Row: int(d.uint()), Col: int(d.uint())})
+		}
+	}
+	if saved.Version >= 3 {
+		if tiles := d.strings(); len(tiles) > 0 {
+			saved.Options.Tiles = tiles
+		}
+	}
+	if saved.Version >= 4 {
+		saved.Nonce = d.string()
+	}
+	if saved.Version >= 5 {
+		for i, count := 0, d.count(); i < count; i++ {
+			if !d.bool() {
+				saved.Readings = append(saved.Readings, nil)
+				continue
+			}
+			r := &reading{At: time.Unix(0, d.int()), Started: time.Unix(0, d.int())}
+			if d.bool() {
+				r.Left = make(map[string]time.Duration)
+				for _, team := range saved.Teams {
+					r.Left[team] = time.Duration(d.int())
+				}
+			}
+			saved.Readings = append(saved.Readings, r)
+		}
+	}
+	return saved, d.finish()
+}
+
+// encoder writes values as varints and length prefixed strings with teams written as their index
+type encoder struct {
+	buf   []byte
+	teams []string
+}
+
+func (e *encoder) uint(v uint64) {
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+func (e *encoder) int(v int64) {
+	e.buf = binary.AppendVarint(e.buf, v)
+}
+
+func (e *encoder) bool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) string(v string) {
+	e.uint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) strings(v []string) {
+	e.uint(uint64(len(v)))
+	for _, s := range v {
+		e.string(s)
+	}
+}
+
+// team writes one more than the team's index leaving zero for no team
+func (e *encoder) team(team string) {
+	e.uint(uint64(indexOf(e.teams, team) + 1))
+}
+
+func (e *encoder) tile(t *tile) {
+	e.string(t.Edges)
+	travelled := t.pathMap()
+	paths := make([]string, 0, len(travelled))
+	for path := range travelled {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	e.uint(uint64(len(paths)))
+	for _, path := range paths {
+		e.string(path)
+		e.team(travelled[path])
+	}
+}
+
+func (e *encoder) tiles(tiles []*tile) {
+	e.uint(uint64(len(tiles)))
+	for _, t := range tiles {
+		e.tile(t)
+	}
+}
+
+// decoder reads what encoder writes stopping at the first error
+type decoder struct {
+	buf   []byte
+	teams []string
+	err   error
+}
+
+func (d *decoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = fmt.Errorf("invalid binary state")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) int() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = fmt.Errorf("invalid binary state")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+// count reads a length making sure it cannot be larger than the remaining data
+func (d *decoder) count() int {
+	v := d.uint()
+	if v > uint64(len(d.buf)) {
+		if d.err == nil {
+			d.err = fmt.Errorf("invalid binary state")
+		}
+		return 0
+	}
+	return int(v)
+}
+
+func (d *decoder) bool() bool {
+	return d.uint() == 1
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	v := string(d.buf[:n])
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) strings() []string {
+	result := make([]string, 0)
+	for i, count := 0, d.count(); i < count; i++ {
+		result = append(result, d.string())
+	}
+	return result
+}
+
+func (d *decoder) team() string {
+	idx := d.uint()
+	if idx == 0 || d.err != nil {
+		return ""
+	}
+	if idx > uint64(len(d.teams)) {
+		d.err = fmt.Errorf("invalid team index %d", idx-1)
+		return ""
+	}
+	return d.teams[idx-1]
+}
+
+func (d *decoder) tile() *tile {
+	t := &tile{Edges: d.string()}
+	if d.err == nil {
+		if shape, err := shapeOf(t.Edges); err != nil {
+			d.err = err
+		} else {
+			t.shape = shape
+		}
+	}
+	for i, count := 0, d.count(); i < count; i++ {
+		path := d.string()
+		team := d.team()
+		if len(path) != 2 || path[0] < 'A' || path[0] > 'H' {
+			if d.err == nil {
+				d.err = fmt.Errorf("invalid path %s", path)
+			}
+			continue
+		}
+		t.travel(toNotch(path), team)
+	}
+	return t
+}
+
+func (d *decoder) tiles() []*tile {
+	result := make([]*tile, 0)
+	for i, count := 0, d.count(); i < count; i++ {
+		result = append(result, d.tile())
+	}
+	return result
+}
+
+// finish fails if any bytes are left over once the whole state has been read
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.buf) > 0 {
+		d.err = fmt.Errorf("%d unexpected trailing bytes", len(d.buf))
+	}
+	return d.err
+}
+
+// validTile checks the tile is one of the tiles in the game in any orientation
+func validTile(t *tile) error {
+	if t == nil {
+		return fmt.Errorf("missing tile")
+	}
+	if _, err := newTile(t.Edges); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validToken checks the token lies on a notch of a square on the board
+func validToken(t *token, size int) error {
+	if t == nil || t.Row < 0 || t.Row >= size || t.Col < 0 || t.Col >= size ||
+		len(t.Notch) != 1 || !strings.Contains("ABCDEFGH", t.Notch) {
+		return fmt.Errorf("invalid token")
+	}
+	return nil
+}
+
+func restoreFailure(err error) error {
+	return &bgerr.Error{
+		Err:    err,
+		Status: bgerr.StatusInvalidOption,
+	}
+}
+
+func copyTiles(tiles []*tile) []*tile {
+	result := make([]*tile, 0, len(tiles))
+	for _, t := range tiles {
+		result = append(result, t.copy())
+	}
+	return result
+}
+
+func copyBools(m map[string]bool) map[string]bool {
+	result := make(map[string]bool)
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+func copyInts(m map[string]int) map[string]int {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]int)
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+func copyPaths(paths map[string][]*PathSegment) map[string][]*PathSegment {
+	result := make(map[string][]*PathSegment)
+	for team, path := range paths {
+		for _, segment := range path {
+			copied := *segment
+			result[team] = append(result[team], &copied)
+		}
+	}
+	return result
+}
+
+func copyEvents(events []*Event) []*Event {
+	var result []*Event
+	for _, event := range events {
+		copied := *event
+		if event.Segment != nil {
+			segment := *event.Segment
+			copied.Segment = &segment
+		}
+		if event.Teams != nil {
+			copied.Teams = append([]string{}, event.Teams...)
+		}
+		result = append(result, &copied)
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package go_tsuro
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroMarshalState(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TsuroMoreOptions
+	}{
+		{name: "classic", options: TsuroMoreOptions{Variant: VariantClassic}},
+		{name: "longest path", options: TsuroMoreOptions{Variant: VariantLongestPath, BoardSize: 5}},
+		{name: "open tiles", options: TsuroMoreOptions{Variant: VariantOpenTiles, NoSuicide: true}},
+		{name: "seas", options: TsuroMoreOptions{Variant: VariantSeas}},
+		{name: "partners", options: TsuroMoreOptions{Variant: VariantPartners, Alliances: [][]string{{TeamA, "TeamC"}, {TeamB, "TeamD"}}}},
+		{name: "duplicates", options: TsuroMoreOptions{Variant: VariantClassic, Tiles: append(append([]string{}, tiles[:15]...), tiles[:15]...)}},
+	}
+	teams := []string{TeamA, TeamB, "TeamC", "TeamD"}
+	play := func(game *Tsuro, random *rand.Rand, placements int) {
+		for i := 0; i < placements && len(game.state.winners) == 0; i++ {
+			targets, _ := game.Placements(game.state.turn)
+			target := targets[random.Intn(len(targets))]
+			err := game.Do(&bg.BoardGameAction{
+				Team:        game.state.turn,
+				ActionType:  ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{Row: target.Row, Column: target.Column, Tile: target.Tile},
+			})
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+		}
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := test.options
+			options.Seed = 7
+			options.RandomTokens = true
+			game := newTestTsuro(t, teams, options)
+			play(game, rand.New(rand.NewSource(1)), 3)
+			// a rotation mid turn is kept even though it is not a placement
+			if hand := game.state.hands[game.state.turn]; len(game.state.winners) == 0 && len(hand.hand) > 0 {
+				tile := hand.hand[0].Edges
+				assert.NoError(t, game.Do(&bg.BoardGameAction{Team: game.state.turn, ActionType: ActionRotateTileRight, MoreDetails: RotateTileActionDetails{Tile: tile}}))
+			}
+
+			// stop the clock so the time taken in the current turn is the same when saving again
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			game.SetClock(clock)
+			jsonState, err := game.MarshalState()
+			assert.NoError(t, err)
+			binaryState, err := game.MarshalStateBinary()
+			assert.NoError(t, err)
+			assert.Less(t, len(binaryState), len(jsonState))
+
+			for _, data := range [][]byte{jsonState, binaryState} {
+				restored, err := UnmarshalState(data)
+				if err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+				expected, _ := game.GetSnapshot()
+				actual, _ := restored.GetSnapshot()
+				assert.Equal(t, expected, actual)
+				assert.Equal(t, game.GetBGN(), restored.GetBGN())
+				assert.Equal(t, game.state.deck.deck, restored.state.deck.deck)
+
+				// saving again gives the same bytes
+				restored.SetClock(clock)
+				again, _ := restored.MarshalStateBinary()
+				assert.Equal(t, binaryState, again)
+
+				// both games continue identically including any randomness
+				original, _ := UnmarshalState(jsonState)
+				play(original, rand.New(rand.NewSource(2)), 100)
+				play(restored, rand.New(rand.NewSource(2)), 100)
+				expected, _ = original.GetSnapshot()
+				actual, _ = restored.GetSnapshot()
+				assert.Equal(t, expected, actual)
+				assert.NoError(t, restored.Undo(1))
+			}
+		})
+	}
+
+	game := newTestTsuro(t, teams, TsuroMoreOptions{})
+	data, _ := game.MarshalStateBinary()
+	for _, corrupt := range [][]byte{nil, []byte("{}"), []byte("TSR"), data[:len(data)-1], append(data, 0)} {
+		_, err := UnmarshalState(corrupt)
+		assert.Error(t, err)
+	}
+
+	// a save claiming more random values than the game could draw is rejected instead of replayed
+	saved := game.save()
+	saved.Drawn = 1 << 62
+	spin, _ := json.Marshal(saved)
+	_, err := UnmarshalState(spin)
+	assert.Error(t, err)
+}
+
+// state_v1.json and state_v1.bin were saved by the first version of the saved layout
+func Test_TsuroUnmarshalStateV1(t *testing.T) {
+	for _, file := range []string{"testdata/state_v1.json", "testdata/state_v1.bin"} {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			restored, err := UnmarshalState(data)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			assert.Equal(t, TimeoutRandom, restored.options.TimeoutMove)
+			actions := make([]string, 0)
+			for _, action := range restored.GetBGN().Actions {
+				actions = append(actions, action.String())
+			}
+			assert.Equal(t, []string{"0p&5.4.CHDFEAGB", "1p&0.0.CFDAEGHB", "2p&0.5.CHDAEBFG", "0r&ADBCEHFG"}, actions)
+
+			// the paths, events, and draws missing from version 1 match replaying the game
+			builder := Builder{}
+			replayed, err := builder.Load(restored.GetBGN())
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			expected, _ := replayed.GetSnapshot(TeamA)
+			actual, _ := restored.GetSnapshot(TeamA)
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, replayed.(*Tsuro).state.deck.deck, restored.state.deck.deck)
+			assert.Equal(t, replayed.(*Tsuro).state.deck.drawn, restored.state.deck.drawn)
+			assert.NotEmpty(t, restored.state.paths[TeamA])
+
+			// saving again writes the current version
+			again, err := restored.MarshalStateBinary()
+			assert.NoError(t, err)
+			current, err := UnmarshalState(again)
+			assert.NoError(t, err)
+			assert.Equal(t, restored.GetBGN(), current.GetBGN())
+		})
+	}
+}
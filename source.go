@@ -0,0 +1,40 @@
+package go_tsuro
+
+import "math/rand"
+
+// source is a seeded random source that counts the values drawn from it so its position can be saved and restored
+type source struct {
+	rand.Source64
+	seed  int64
+	drawn uint64
+}
+
+func newSource(seed int64) *source {
+	return &source{
+		Source64: rand.NewSource(seed).(rand.Source64),
+		seed:     seed,
+	}
+}
+
+func (s *source) Int63() int64 {
+	s.drawn++
+	return s.Source64.Int63()
+}
+
+func (s *source) Uint64() uint64 {
+	s.drawn++
+	return s.Source64.Uint64()
+}
+
+func (s *source) Seed(seed int64) {
+	s.Source64.Seed(seed)
+	s.seed = seed
+	s.drawn = 0
+}
+
+// advance skips ahead n values without the cost of replaying the actions that drew them
+func (s *source) advance(n uint64) {
+	for i := uint64(0); i < n; i++ {
+		s.Uint64()
+	}
+}
@@ -22,20 +22,54 @@ type state struct {
 	alive           map[string]bool // teams that are alive
 	variant         string
 	points          map[string]int
+	noSuicide       bool       // placements that eliminate the placing team are only allowed when every placement does
+	alliances       [][]string // groups of teams that share a hand and win together
+	daikaiju        []*daikaiju
+	destroyed       []*tile                      // tiles destroyed by daikaiju
+	daikaijuMove    *MoveDaikaijuActionDetails   // rolls made after the latest placement
+	scriptedMoves   []*MoveDaikaijuActionDetails // recorded rolls made in place of rolling when replaying a game without its seed
+	paths           map[string][]*PathSegment    // route travelled by each token in order
+	sharedTiles     bool                         // tiles on the board belong to another state so are copied before they change
+	events          []*Event                     // what happened during the latest placement
+	observers       []Observer
 }
 
-func newState(teams []string, random *rand.Rand, variant string) (*state, error) {
+// newState deals a new game using script in place of the random deals, tokens, and rolls if it is not nil
+func newState(teams []string, random *rand.Rand, options *TsuroMoreOptions, script *script) (*state, error) {
 	if random == nil {
 		return nil, fmt.Errorf("random seed is null")
 	}
+	variant := options.Variant
+	size := options.BoardSize
+	if size == 0 && variant == VariantSeas {
+		size = seasBoardSize
+	} else if size == 0 {
+		size = defaultBoardSize
+	}
+	if variant == VariantSeas && size != seasBoardSize {
+		return nil, fmt.Errorf("%s must be played on a %dx%d board", VariantSeas, seasBoardSize, seasBoardSize)
+	}
+	if size < minBoardSize || size > maxBoardSize {
+		return nil, fmt.Errorf("board size %d must be between %d and %d", size, minBoardSize, maxBoardSize)
+	}
 	hands := make(map[string]*hand)
 	tokens := make(map[string]*token)
 	alive := make(map[string]bool)
-	deck := newDeck(random)
+	set := deckTiles(options)
+	for _, edges := range set {
+		if _, err := newTile(edges); err != nil {
+			return nil, err
+		}
+	}
+	deck := newDeck(random, set)
 	points := make(map[string]int)
+	if script != nil {
+		deck.stacked = append([]string{}, script.draws...)
+		deck.reserve(script.placed)
+	}
 
 	switch variant {
-	case VariantClassic, VariantSolo:
+	case VariantClassic, VariantSolo, VariantSeas:
 		for _, team := range teams {
 			hand := newHand()
 			for i := 0; i < 3; i++ {
@@ -46,8 +80,9 @@ func newState(teams []string, random *rand.Rand, variant string) (*state, error)
 				hand.Add(tile)
 			}
 			hands[team] = hand
-			token := uniqueRandomToken(tokens, random)
-			tokens[team] = token
+			if options.RandomTokens {
+				tokens[team] = uniqueRandomToken(tokens, random, size, size)
+			}
 			alive[team] = true
 		}
 	case VariantLongestPath, VariantMostCrossings:
@@ -61,11 +96,35 @@ func newState(teams []string, random *rand.Rand, variant string) (*state, error)
 				hand.Add(tile)
 			}
 			hands[team] = hand
-			token := uniqueRandomToken(tokens, random)
-			tokens[team] = token
+			if options.RandomTokens {
+				tokens[team] = uniqueRandomToken(tokens, random, size, size)
+			}
 			alive[team] = true
 			points[team] = 0
 		}
+	case VariantPartners:
+		if err := validAlliances(teams, options.Alliances); err != nil {
+			return nil, err
+		}
+		for _, alliance := range options.Alliances {
+			hand := newHand()
+			for i := 0; i < 3; i++ {
+				tile, err := deck.Draw()
+				if err != nil {
+					return nil, err
+				}
+				hand.Add(tile)
+			}
+			for _, team := range alliance {
+				hands[team] = hand
+			}
+		}
+		for _, team := range teams {
+			if options.RandomTokens {
+				tokens[team] = uniqueRandomToken(tokens, random, size, size)
+			}
+			alive[team] = true
+		}
 	case VariantOpenTiles:
 		hand := newHand()
 		for i := 0; i < 3; i++ {
@@ -77,21 +136,39 @@ func newState(teams []string, random *rand.Rand, variant string) (*state, error)
 		}
 		for _, team := range teams {
 			hands[team] = hand
-			token := uniqueRandomToken(tokens, random)
-			tokens[team] = token
+			if options.RandomTokens {
+				tokens[team] = uniqueRandomToken(tokens, random, size, size)
+			}
 			alive[team] = true
 		}
 	default:
 		return nil, fmt.Errorf("invalid variant %s", variant)
 	}
-	if len(teams) != len(tokens) {
+	if len(teams) != len(alive) {
 		return nil, fmt.Errorf("failed to build new state likely due to duplicate teams")
 	}
+	if variant != VariantPartners && len(options.Alliances) > 0 {
+		return nil, fmt.Errorf("alliances are only allowed in the %s variant", VariantPartners)
+	}
+	var monsters []*daikaiju
+	if variant == VariantSeas {
+		monsters = randomDaikaiju(random, size, size, seasDaikaiju)
+	}
+	var moves []*MoveDaikaijuActionDetails
+	if script != nil {
+		if options.RandomTokens {
+			tokens = script.startingTokens()
+		}
+		if variant == VariantSeas {
+			monsters = script.startingDaikaiju()
+		}
+		moves = append(moves, script.moves...)
+	}
 	return &state{
 		turn:            teams[0],
 		teams:           teams,
 		winners:         make([]string, 0),
-		board:           newBoard(),
+		board:           newBoard(size, size),
 		deck:            deck,
 		tokens:          tokens,
 		hands:           hands,
@@ -100,88 +177,152 @@ func newState(teams []string, random *rand.Rand, variant string) (*state, error)
 		alive:           alive,
 		variant:         variant,
 		points:          points,
+		noSuicide:       options.NoSuicide,
+		alliances:       options.Alliances,
+		daikaiju:        monsters,
+		destroyed:       make([]*tile, 0),
+		paths:           make(map[string][]*PathSegment),
+		scriptedMoves:   moves,
 	}, nil
 }
 
-func (s *state) RotateTileRight(team, tile string) error {
+// RotateTileRight rotates tile in team's hand, or the copy of it at index when index is not negative, returning the index of the rotated tile in the hand
+func (s *state) RotateTileRight(team, tile string, index int) (int, error) {
 	if s.variant == VariantOpenTiles && team != s.turn {
-		return &bgerr.Error{
+		return -1, &bgerr.Error{
 			Err:    fmt.Errorf("%s cannot rotate tile on %s turn", team, s.turn),
 			Status: bgerr.StatusWrongTurn,
 		}
 	}
 	if !contains(s.teams, team) {
-		return &bgerr.Error{
+		return -1, &bgerr.Error{
 			Err:    fmt.Errorf("%s not a valid team", team),
 			Status: bgerr.StatusUnknownTeam,
 		}
 	}
+	idx, err := s.handIndex(team, tile, index)
+	if err != nil {
+		return -1, err
+	}
+	s.hands[team].hand[idx].RotateRight()
+	return idx, nil
+}
+
+// RotateTileLeft rotates tile in team's hand, or the copy of it at index when index is not negative, returning the index of the rotated tile in the hand
+func (s *state) RotateTileLeft(team, tile string, index int) (int, error) {
+	if s.variant == VariantOpenTiles && team != s.turn {
+		return -1, &bgerr.Error{
+			Err:    fmt.Errorf("%s cannot rotate tile on %s turn", team, s.turn),
+			Status: bgerr.StatusWrongTurn,
+		}
+	}
+	if !contains(s.teams, team) {
+		return -1, &bgerr.Error{
+			Err:    fmt.Errorf("%s not a valid team", team),
+			Status: bgerr.StatusUnknownTeam,
+		}
+	}
+	idx, err := s.handIndex(team, tile, index)
+	if err != nil {
+		return -1, err
+	}
+	s.hands[team].hand[idx].RotateLeft()
+	return idx, nil
+}
+
+// handIndex returns the index of tile in team's hand checking the tile at index is a copy of it when index is not negative
+func (s *state) handIndex(team, tile string, index int) (int, error) {
 	t, err := newTile(tile)
 	if err != nil {
-		return &bgerr.Error{
+		return -1, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidActionDetails,
 		}
 	}
-	if !t.in(s.hands[team].hand) {
-		return &bgerr.Error{
+	hand := s.hands[team]
+	if index >= 0 {
+		if index >= len(hand.hand) || !t.equals(hand.hand[index]) {
+			return -1, &bgerr.Error{
+				Err:    fmt.Errorf("%s's hand does not contain %s at %d", team, tile, index),
+				Status: bgerr.StatusInvalidActionDetails,
+			}
+		}
+		return index, nil
+	}
+	if !t.in(hand.hand) {
+		return -1, &bgerr.Error{
 			Err:    fmt.Errorf("%s's hand does not contain %s", team, tile),
 			Status: bgerr.StatusInvalidActionDetails,
 		}
 	}
-	s.hands[team].hand[s.hands[team].IndexOf(t)].RotateRight()
-	return nil
+	return hand.IndexOf(t), nil
 }
 
-func (s *state) RotateTileLeft(team, tile string) error {
-	if s.variant == VariantOpenTiles && team != s.turn {
+func (s *state) PlaceToken(team string, row, column int, notch string) error {
+	if !s.placingTokens() {
 		return &bgerr.Error{
-			Err:    fmt.Errorf("%s cannot rotate tile on %s turn", team, s.turn),
-			Status: bgerr.StatusWrongTurn,
+			Err:    fmt.Errorf("all tokens have already been placed"),
+			Status: bgerr.StatusInvalidAction,
 		}
 	}
-	if !contains(s.teams, team) {
+	if team != s.turn {
 		return &bgerr.Error{
-			Err:    fmt.Errorf("%s not a valid team", team),
-			Status: bgerr.StatusUnknownTeam,
+			Err:    fmt.Errorf("%s cannot play on %s turn", team, s.turn),
+			Status: bgerr.StatusWrongTurn,
 		}
 	}
-	t, err := newTile(tile)
-	if err != nil {
+	if len(notch) != 1 || !strings.Contains(notchNames, notch) {
 		return &bgerr.Error{
-			Err:    err,
+			Err:    fmt.Errorf("%s is not a valid notch", notch),
 			Status: bgerr.StatusInvalidActionDetails,
 		}
 	}
-	if !t.in(s.hands[team].hand) {
+	t := newToken(row, column, notch)
+	if !t.onEdge(s.board.rows, s.board.columns) {
 		return &bgerr.Error{
-			Err:    fmt.Errorf("%s's hand does not contain %s", team, tile),
-			Status: bgerr.StatusInvalidActionDetails,
+			Err:    fmt.Errorf("row %d column %d notch %s is not on the edge of the board", row, column, notch),
+			Status: bgerr.StatusInvalidAction,
 		}
 	}
-	s.hands[team].hand[s.hands[team].IndexOf(t)].RotateLeft()
+	for _, token := range s.tokens {
+		if token.Row == row && token.Col == column {
+			return &bgerr.Error{
+				Err:    fmt.Errorf("a token already starts in row %d column %d", row, column),
+				Status: bgerr.StatusInvalidAction,
+			}
+		}
+	}
+	s.tokens[team] = t
+	s.nextTurn()
 	return nil
 }
 
-func (s *state) PlaceTile(team, tile string, row, column int) error {
+// PlaceTile places team's tile and moves every token returning what happened in the order it happened
+func (s *state) PlaceTile(team, tile string, row, column int) ([]*Event, error) {
 	if team != s.turn {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    fmt.Errorf("%s cannot play on %s turn", team, s.turn),
 			Status: bgerr.StatusWrongTurn,
 		}
 	}
+	if s.placingTokens() {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("all tokens must be placed before placing tiles"),
+			Status: bgerr.StatusInvalidAction,
+		}
+	}
 	if !s.playedFirstTurn[s.turn] && (s.tokens[team].Row != row || s.tokens[team].Col != column) {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    fmt.Errorf("%s cannot place in row %d column %d", team, row, column),
 			Status: bgerr.StatusInvalidAction,
 		}
 	} else if s.playedFirstTurn[s.turn] {
-		adj, err := s.tokens[team].getAdjacent()
+		adj, err := s.tokens[team].getAdjacent(s.board.rows, s.board.columns)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if row != adj.Row || column != adj.Col {
-			return &bgerr.Error{
+			return nil, &bgerr.Error{
 				Err:    fmt.Errorf("%s cannot place in row %d column %d", team, row, column),
 				Status: bgerr.StatusInvalidAction,
 			}
@@ -189,40 +330,66 @@ func (s *state) PlaceTile(team, tile string, row, column int) error {
 	}
 	t, err := newTile(tile)
 	if err != nil {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidActionDetails,
 		}
 	}
 	if !t.in(s.hands[team].hand) {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    fmt.Errorf("%s's hand does not contain %s", team, tile),
 			Status: bgerr.StatusInvalidAction,
 		}
 	}
+	if s.daikaijuAt(row, column) {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("cannot place in row %d column %d as a daikaiju is there", row, column),
+			Status: bgerr.StatusInvalidAction,
+		}
+	}
+	// a replay of a secret seed game in progress cannot tell whether the hidden tiles in hand would have kept the team on the board
+	if s.noSuicide && !s.deck.guessed(s.hands[team].hand) && contains(s.simulate(team, t, row, column), team) && s.canSurvive(team) {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("%s cannot place a tile that eliminates themselves while another placement keeps them on the board", team),
+			Status: bgerr.StatusInvalidAction,
+		}
+	}
+	held := s.hands[team].hand[s.hands[team].IndexOf(t)]
 	if err := s.hands[team].Remove(t); err != nil {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidAction,
 		}
 	}
 	if err := s.board.Place(t, row, column); err != nil {
-		return &bgerr.Error{
+		return nil, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidAction,
 		}
 	}
+	s.deck.place(held, s.hands[team].hand)
+	s.events = make([]*Event, 0)
+	s.record(&Event{
+		Type:   EventTilePlaced,
+		Team:   team,
+		Row:    row,
+		Column: column,
+		Tile:   t.Edges,
+	})
 	if !s.playedFirstTurn[s.turn] {
 		s.playedFirstTurn[s.turn] = true
 	}
-	s.moveTokens()
+	looped := s.moveTokens()
 	if s.variant == VariantLongestPath || s.variant == VariantMostCrossings {
 		s.score()
 	}
-	s.updateAlive()
+	s.updateAlive(looped)
+	if s.variant == VariantSeas {
+		s.moveDaikaiju()
+	}
 	s.handleDraws()
 	s.nextTurn()
-	return nil
+	return s.events, nil
 }
 
 func (s *state) SetWinners(winners []string) error {
@@ -235,20 +402,46 @@ func (s *state) SetWinners(winners []string) error {
 		}
 	}
 	s.winners = winners
+	s.notifyGameOver()
 	return nil
 }
 
-func (s *state) moveTokens() {
+// moveTokens moves every token as far as its path goes returning the teams whose token went round a loop back to where
+// it started which can only happen once daikaiju have destroyed tiles
+func (s *state) moveTokens() []string {
+	starts := make([]token, len(s.teams))
+	looping := make([]bool, len(s.teams))
+	for idx, team := range s.teams {
+		if token, ok := s.tokens[team]; ok {
+			starts[idx] = *token
+		}
+	}
+	for s.stepTokens(starts, looping) > 0 {
+	}
+	looped := make([]string, 0)
+	for idx, team := range s.teams {
+		if looping[idx] {
+			looped = append(looped, team)
+		}
+	}
+	return looped
+}
+
+// stepTokens moves each token that can move across one more tile stopping tokens that arrive back at their start
+// and returns how many tokens moved
+func (s *state) stepTokens(starts []token, looping []bool) int {
 	moved := 0
-	move := map[string]string{"A": "F", "B": "E", "C": "H", "D": "G", "E": "B", "F": "A", "G": "D", "H": "C"}
-	for team, token := range s.tokens {
-		if s.playedFirstTurn[team] {
+	for idx, team := range s.teams {
+		token, ok := s.tokens[team]
+		if ok && s.playedFirstTurn[team] && !looping[idx] {
 			t := s.board.board[token.Row][token.Col]
-			if !mapContainsVal(t.Paths, team) {
+			if !t.travelled(team) {
 				// first placement so move through the just placed tile
-				destination := t.GetDestination(token.Notch)
-				t.Paths[token.Notch+destination] = team
-				token.Notch = destination
+				start := toNotch(token.Notch)
+				destination := t.destination(start)
+				s.travelTile(token.Row, token.Col, start, team)
+				s.travel(team, token.Row, token.Col, token.Notch, destination.String())
+				token.Notch = destination.String()
 				// token was moved
 				moved++
 			} else if s.collided(s.tokens, team, token) {
@@ -256,37 +449,69 @@ func (s *state) moveTokens() {
 				continue
 			} else {
 				// normal case
-				var nextTile *tile
-				if strings.Contains("AB", token.Notch) && token.Row-1 >= 0 && s.board.board[token.Row-1][token.Col] != nil {
-					nextTile = s.board.board[token.Row-1][token.Col]
-					token.Row -= 1
-				} else if strings.Contains("CD", token.Notch) && token.Col+1 < columns && s.board.board[token.Row][token.Col+1] != nil {
-					nextTile = s.board.board[token.Row][token.Col+1]
-					token.Col += 1
-				} else if strings.Contains("EF", token.Notch) && token.Row+1 < rows && s.board.board[token.Row+1][token.Col] != nil {
-					nextTile = s.board.board[token.Row+1][token.Col]
-					token.Row += 1
-				} else if strings.Contains("GH", token.Notch) && token.Col-1 >= 0 && s.board.board[token.Row][token.Col-1] != nil {
-					nextTile = s.board.board[token.Row][token.Col-1]
-					token.Col -= 1
-				} else {
+				n := toNotch(token.Notch)
+				row, col := token.Row+steps[n][0], token.Col+steps[n][1]
+				if row < 0 || col < 0 || row >= s.board.rows || col >= s.board.columns || s.board.board[row][col] == nil {
 					continue
 				}
+				nextTile := s.board.board[row][col]
+				token.Row, token.Col = row, col
 				// move the token to the notch on the next tile
-				startNotch := move[token.Notch]
+				startNotch := facing[n]
 				// where the token ends up on the next tile
-				endNotch := nextTile.GetDestination(startNotch)
+				endNotch := nextTile.destination(startNotch)
 				// update token location
-				nextTile.Paths[startNotch+endNotch] = team
-				token.Notch = endNotch
+				s.travelTile(row, col, startNotch, team)
+				s.travel(team, row, col, startNotch.String(), endNotch.String())
+				token.Notch = endNotch.String()
 				// token was moved
 				moved++
+				looping[idx] = *token == starts[idx]
 			}
 		}
 	}
-	if moved > 0 {
-		s.moveTokens()
+	return moved
+}
+
+// travelTile records team entering the tile at row and col by entry copying the tile first when the board shares its tiles
+func (s *state) travelTile(row, col int, entry notch, team string) {
+	t := s.board.board[row][col]
+	if s.sharedTiles {
+		t = t.copy()
+		s.board.board[row][col] = t
+	}
+	t.travel(entry, team)
+}
+
+// travel adds a segment to the end of team's route
+func (s *state) travel(team string, row, col int, entry, exit string) {
+	if s.paths == nil {
+		return // routes are not kept when simulating placements
+	}
+	segment := &PathSegment{
+		Row:    row,
+		Column: col,
+		Entry:  entry,
+		Exit:   exit,
+	}
+	s.paths[team] = append(s.paths[team], segment)
+	copied := *segment
+	s.record(&Event{
+		Type:    EventTokenMoved,
+		Team:    team,
+		Segment: &copied,
+	})
+}
+
+// collidedWith lists in order the teams whose tokens team's token collided with
+func (s *state) collidedWith(team string, token *token) []string {
+	teams := make([]string, 0)
+	for _, team2 := range s.teams {
+		if token2, ok := s.tokens[team2]; ok && team != team2 && (token.collided(token2) || token.equals(token2)) {
+			teams = append(teams, team2)
+		}
 	}
+	return teams
 }
 
 func (s *state) collided(tokens map[string]*token, team string, token *token) bool {
@@ -309,7 +534,9 @@ func (s *state) score() {
 			for _, tile := range row {
 				if tile != nil {
 					for _, team := range tile.Paths {
-						points[team]++
+						if team != "" {
+							points[team]++
+						}
 					}
 				}
 			}
@@ -333,64 +560,204 @@ func (s *state) score() {
 	}
 }
 
-func (s *state) updateAlive() {
+func (s *state) updateAlive(looped []string) {
 	if len(s.winners) > 0 {
 		return
 	}
 	// alive before checking
-	initialAlive := make([]string, 0)
-	for _, team := range s.teams {
-		if s.alive[team] {
-			initialAlive = append(initialAlive, team)
-		}
-	}
+	initialAlive := s.aliveTeams()
 	// update who is still alive
-	for team, token := range s.tokens {
-		if s.playedFirstTurn[team] {
-			if (token.Row == 0 && strings.Contains("AB", token.Notch)) ||
-				(token.Row == rows-1 && strings.Contains("EF", token.Notch)) ||
-				(token.Col == 0 && strings.Contains("GH", token.Notch)) ||
-				(token.Col == columns-1 && strings.Contains("CD", token.Notch)) {
+	s.eliminate(looped)
+	s.updateWinners(initialAlive)
+}
+
+// eliminate knocks out every token that is on the board edge, collided with another token, sailed into a daikaiju,
+// or is one of the looped tokens caught going round a loop
+func (s *state) eliminate(looped []string) {
+	for _, team := range s.teams {
+		token, ok := s.tokens[team]
+		if ok && s.playedFirstTurn[team] {
+			if contains(looped, team) {
+				// check caught in a loop
+				s.record(&Event{Type: EventLooped, Team: team})
+				s.setLost(team)
+			} else if token.onEdge(s.board.rows, s.board.columns) {
 				// check on board edge
+				s.record(&Event{Type: EventOffEdge, Team: team})
 				s.setLost(team)
 			} else if s.collided(s.tokens, team, token) {
 				// check if collided with another token
+				s.record(&Event{Type: EventCollided, Team: team, Teams: s.collidedWith(team, token)})
+				s.setLost(team)
+			} else if s.facingDaikaiju(token) {
+				// check if facing a daikaiju
+				s.record(&Event{Type: EventDaikaiju, Team: team})
 				s.setLost(team)
 			}
 		}
 	}
+}
+
+// updateWinners decides if the game is over given the teams that were alive before the latest eliminations
+func (s *state) updateWinners(initialAlive []string) {
+	over := len(s.winners) > 0
 	// who is still alive
-	stillAlive := make([]string, 0)
-	for _, team := range s.teams {
-		if s.alive[team] {
-			stillAlive = append(stillAlive, team)
-		}
-	}
+	stillAlive := s.aliveTeams()
 	switch s.variant {
-	case VariantClassic, VariantOpenTiles:
+	case VariantClassic, VariantOpenTiles, VariantSeas:
 		if len(stillAlive) == 0 { // no more alive so initial alive all win
 			s.winners = initialAlive
 		} else if len(stillAlive) == 1 { // one alive so they win
 			s.winners = stillAlive
-		} else if s.board.getTileCount() == len(tiles) { // all tiles have been placed remaining alive are winners
+		} else if s.allTilesPlaced() { // all tiles have been placed remaining alive are winners
 			s.winners = stillAlive
 		}
 	case VariantLongestPath, VariantMostCrossings:
-		max := max(s.points)
+		max := max(s.teams, s.points)
 		if len(stillAlive) == 0 { // no more alive
 			s.winners = max
-		} else if s.board.getTileCount() == len(tiles) { // all tiles have been placed
+		} else if s.allTilesPlaced() { // all tiles have been placed
 			s.winners = max
 		} else if len(stillAlive) == 1 && len(max) == 1 && max[0] == stillAlive[0] { // last remaining has the most points to wins
 			s.winners = max
 		}
+	case VariantPartners:
+		aliveAlliances := s.alliancesOf(stillAlive)
+		if len(aliveAlliances) == 0 { // no more alive so alliances alive before all win
+			s.winners = flatten(s.alliancesOf(initialAlive))
+		} else if len(aliveAlliances) == 1 { // one alliance alive so it wins
+			s.winners = flatten(aliveAlliances)
+		} else if s.allTilesPlaced() { // all tiles have been placed remaining alliances are winners
+			s.winners = flatten(aliveAlliances)
+		}
 	case VariantSolo:
 		if len(stillAlive) == 0 {
 			s.winners = []string{"FAIL"}
-		} else if s.board.getTileCount() == len(tiles) { // win if all tokens are still on board and all tiles have been placed
+		} else if s.allTilesPlaced() { // win if all tokens are still on board and all tiles have been placed
 			s.winners = stillAlive
 		}
 	}
+	if !over && len(s.winners) > 0 {
+		s.notifyGameOver()
+	}
+}
+
+// moveDaikaiju rolls two dice and on a 6, 7, or 8 rolls a die for each daikaiju to decide where it moves
+func (s *state) moveDaikaiju() {
+	s.daikaijuMove = nil
+	if len(s.winners) > 0 || len(s.daikaiju) == 0 {
+		return
+	}
+	if len(s.scriptedMoves) > 0 {
+		s.daikaijuMove = s.scriptedMoves[0]
+		s.scriptedMoves = s.scriptedMoves[1:]
+		s.applyDaikaijuMove(s.daikaijuMove)
+		return
+	}
+	move := &MoveDaikaijuActionDetails{
+		Roll:       s.roll() + s.roll(),
+		Directions: make([]int, 0),
+	}
+	if awakens(move.Roll) {
+		for range s.daikaiju {
+			move.Directions = append(move.Directions, s.roll())
+		}
+	}
+	s.daikaijuMove = move
+	s.applyDaikaijuMove(move)
+}
+
+// applyDaikaijuMove moves each daikaiju in the rolled direction destroying the tiles and tokens
+// on the squares they move into and removing those that move off the board
+func (s *state) applyDaikaijuMove(move *MoveDaikaijuActionDetails) {
+	if !awakens(move.Roll) {
+		return
+	}
+	initialAlive := s.aliveTeams()
+	remaining := make([]*daikaiju, 0)
+	for idx, d := range s.daikaiju {
+		if idx >= len(move.Directions) {
+			remaining = append(remaining, d)
+			continue
+		}
+		row, col := d.direction(move.Directions[idx])
+		if row < 0 || col < 0 || row >= s.board.rows || col >= s.board.columns {
+			// swims off the board
+			continue
+		}
+		remaining = append(remaining, d)
+		if s.daikaijuAt(row, col) {
+			// blocked by another daikaiju
+			continue
+		}
+		if tile := s.board.board[row][col]; tile != nil {
+			s.board.board[row][col] = nil
+			s.destroyed = append(s.destroyed, tile)
+			s.record(&Event{Type: EventTileDestroyed, Row: row, Column: col, Tile: tile.Edges})
+		}
+		for _, team := range s.teams {
+			if token := s.tokens[team]; s.alive[team] && token != nil && token.Row == row && token.Col == col {
+				s.record(&Event{Type: EventDaikaiju, Team: team})
+				s.setLost(team)
+			}
+		}
+		d.Row, d.Col = row, col
+	}
+	s.daikaiju = remaining
+	s.eliminate(nil)
+	s.updateWinners(initialAlive)
+}
+
+// roll returns the result of rolling a six sided die using the same randomness as the deck
+func (s *state) roll() int {
+	return s.deck.random.Intn(6) + 1
+}
+
+func (s *state) daikaijuAt(row, col int) bool {
+	for _, d := range s.daikaiju {
+		if d.Row == row && d.Col == col {
+			return true
+		}
+	}
+	return false
+}
+
+// facingDaikaiju is true when the token's next square holds a daikaiju
+func (s *state) facingDaikaiju(token *token) bool {
+	if len(s.daikaiju) == 0 {
+		return false
+	}
+	adj, err := token.getAdjacent(s.board.rows, s.board.columns)
+	if err != nil {
+		return false
+	}
+	return s.daikaijuAt(adj.Row, adj.Col)
+}
+
+func (s *state) aliveTeams() []string {
+	alive := make([]string, 0)
+	for _, team := range s.teams {
+		if s.alive[team] {
+			alive = append(alive, team)
+		}
+	}
+	return alive
+}
+
+// allTilesPlaced is true once the board is full or there are no tiles left in the deck or any hand
+func (s *state) allTilesPlaced() bool {
+	if s.board.isFull() {
+		return true
+	}
+	if len(s.deck.deck) > 0 {
+		return false
+	}
+	for _, hand := range s.hands {
+		if len(hand.hand) > 0 {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *state) handleDraws() {
@@ -400,6 +767,9 @@ func (s *state) handleDraws() {
 	current := s.turn
 	if s.dragon != "" {
 		current = s.dragon
+	} else if partner := s.partner(current); !s.alive[current] && partner != "" {
+		// the hand of a team that knocked itself out is refilled for the team still sharing it
+		current = partner
 	}
 	for s.alive[current] && len(s.deck.deck) > 0 && len(s.hands[current].hand) < 3 {
 		tile, err := s.deck.Draw()
@@ -407,20 +777,75 @@ func (s *state) handleDraws() {
 			return
 		}
 		s.hands[current].Add(tile)
+		s.record(&Event{Type: EventTileDrawn, Team: current, Tile: tile.Edges})
 		current = s.getNextTurn(current)
 	}
 	if len(s.deck.deck) == 0 && len(s.hands[current].hand) < 3 {
-		s.dragon = current
+		s.passDragon(current)
 	} else {
-		s.dragon = ""
+		s.passDragon("")
+	}
+}
+
+// Resign knocks team out of the game at any point returning their tiles to the deck
+func (s *state) Resign(team string) error {
+	if !contains(s.teams, team) {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("%s not a valid team", team),
+			Status: bgerr.StatusUnknownTeam,
+		}
+	}
+	if !s.alive[team] {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("%s is already out of the game", team),
+			Status: bgerr.StatusInvalidAction,
+		}
 	}
+	s.forfeit(team)
+	return nil
+}
+
+// forfeit knocks team out of the game passing on their turn if it was theirs
+func (s *state) forfeit(team string) {
+	if !s.alive[team] || len(s.winners) > 0 {
+		return
+	}
+	initialAlive := s.aliveTeams()
+	s.events = make([]*Event, 0)
+	s.record(&Event{Type: EventForfeit, Team: team})
+	s.setLost(team)
+	s.updateWinners(initialAlive)
+	if s.dragon != "" {
+		// the dragon tile holder draws from the tiles returned to the deck
+		s.handleDraws()
+	}
+	if s.turn == team {
+		s.nextTurn()
+	}
+}
+
+// passDragon gives the dragon tile to team or returns it when team is empty
+func (s *state) passDragon(team string) {
+	if s.dragon == team {
+		return
+	}
+	s.dragon = team
+	s.record(&Event{Type: EventDragonPassed, Team: team})
 }
 
 func (s *state) nextTurn() {
 	if len(s.winners) > 0 {
 		return
 	}
+	turn := s.turn
 	s.turn = s.getNextTurn(s.turn)
+	// teams without tiles pass once the deck is empty
+	for i := 0; i < len(s.teams) && len(s.deck.deck) == 0 && len(s.hands[s.turn].hand) == 0; i++ {
+		s.turn = s.getNextTurn(s.turn)
+	}
+	if s.turn != turn {
+		s.notifyTurn()
+	}
 }
 
 func (s *state) getNextTurn(turn string) string {
@@ -443,15 +868,66 @@ func (s *state) getNextTurn(turn string) string {
 func (s *state) setLost(team string) {
 	s.alive[team] = false
 	s.playedFirstTurn[team] = false
-	s.deck.Add(s.hands[team].hand...)
-	s.hands[team].Clear()
+	if !s.partnerAlive(team) {
+		s.deck.Add(s.hands[team].hand...)
+		s.hands[team].Clear()
+	}
 	if s.aliveCount() <= 0 {
 		return
 	}
 	next := s.getNextTurn(s.turn)
 	if s.dragon == team && len(s.hands[next].hand) < 3 {
-		s.dragon = next
+		s.passDragon(next)
+	}
+}
+
+// alliance returns the teams allied with team including team itself
+func (s *state) alliance(team string) []string {
+	for _, alliance := range s.alliances {
+		if contains(alliance, team) {
+			return alliance
+		}
+	}
+	return []string{team}
+}
+
+// partnerAlive is true if any team sharing team's hand, such as an ally or every team in OpenTiles, is still alive
+func (s *state) partnerAlive(team string) bool {
+	return s.partner(team) != ""
+}
+
+// partner returns the first team still alive that shares team's hand or "" if there is none
+func (s *state) partner(team string) string {
+	for _, partner := range s.teams {
+		if partner != team && s.alive[partner] && s.hands[partner] == s.hands[team] {
+			return partner
+		}
+	}
+	return ""
+}
+
+// alliancesOf lists in order the alliances with at least one of the given teams
+func (s *state) alliancesOf(teams []string) [][]string {
+	result := make([][]string, 0)
+	for _, alliance := range s.alliances {
+		for _, team := range alliance {
+			if contains(teams, team) {
+				result = append(result, alliance)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// placingTokens is true while some team still has to choose its starting notch
+func (s *state) placingTokens() bool {
+	for _, team := range s.teams {
+		if s.alive[team] && s.tokens[team] == nil {
+			return true
+		}
 	}
+	return false
 }
 
 func (s *state) aliveCount() int {
@@ -472,64 +948,77 @@ func (s *state) targets(team ...string) []*bg.BoardGameAction {
 			if s.variant == VariantOpenTiles && t != s.turn {
 				continue
 			}
-			for _, tile := range s.hands[t].hand {
+			if s.variant == VariantPartners && s.alliance(t)[0] != t {
+				continue // partners share a hand so only list its tiles once
+			}
+			for i, tile := range s.hands[t].hand {
+				index := i
 				targets = append(targets, &bg.BoardGameAction{
 					Team:       s.turn,
 					ActionType: ActionRotateTileLeft,
 					MoreDetails: RotateTileActionDetails{
-						Tile: tile.Edges,
+						Tile:  tile.Edges,
+						Index: &index,
 					},
 				}, &bg.BoardGameAction{
 					Team:       s.turn,
 					ActionType: ActionRotateTileRight,
 					MoreDetails: RotateTileActionDetails{
-						Tile: tile.Edges,
+						Tile:  tile.Edges,
+						Index: &index,
 					},
 				})
 			}
 		}
 	} else if len(team) == 1 {
 		if (s.variant == VariantOpenTiles && team[0] == s.turn) || s.variant != VariantOpenTiles {
-			for _, tile := range s.hands[team[0]].hand {
+			for i, tile := range s.hands[team[0]].hand {
+				index := i
 				targets = append(targets, &bg.BoardGameAction{
 					Team:       s.turn,
 					ActionType: ActionRotateTileLeft,
 					MoreDetails: RotateTileActionDetails{
-						Tile: tile.Edges,
+						Tile:  tile.Edges,
+						Index: &index,
 					},
 				}, &bg.BoardGameAction{
 					Team:       s.turn,
 					ActionType: ActionRotateTileRight,
 					MoreDetails: RotateTileActionDetails{
-						Tile: tile.Edges,
+						Tile:  tile.Edges,
+						Index: &index,
 					},
 				})
 			}
 		}
 	}
-	// place tile actions
+	// place token actions
+	if s.placingTokens() {
+		if len(team) == 0 || (len(team) == 1 && team[0] == s.turn) {
+			for _, token := range s.startingTokens() {
+				targets = append(targets, &bg.BoardGameAction{
+					Team:       s.turn,
+					ActionType: ActionPlaceToken,
+					MoreDetails: PlaceTokenActionDetails{
+						Row:    token.Row,
+						Column: token.Col,
+						Notch:  token.Notch,
+					},
+				})
+			}
+		}
+		return targets
+	}
+	// place tile actions in every orientation leaving out avoidable self eliminations when NoSuicide is set
 	if len(team) == 0 || (len(team) == 1 && team[0] == s.turn) {
-		row := s.tokens[s.turn].Row
-		col := s.tokens[s.turn].Col
-		switch s.tokens[s.turn].Notch {
-		case "A", "B":
-			row++
-		case "C", "D":
-			col++
-		case "E", "F":
-			row--
-		case "G", "H":
-			col--
-		default:
-		}
-		for _, tile := range s.hands[s.turn].hand {
+		for _, placement := range s.legalPlacements(s.turn) {
 			targets = append(targets, &bg.BoardGameAction{
 				Team:       s.turn,
 				ActionType: ActionPlaceTile,
 				MoreDetails: PlaceTileActionDetails{
-					Row:    row,
-					Column: col,
-					Tile:   tile.Edges,
+					Row:    placement.Row,
+					Column: placement.Column,
+					Tile:   placement.Tile,
 				},
 			})
 		}
@@ -537,15 +1026,160 @@ func (s *state) targets(team ...string) []*bg.BoardGameAction {
 	return targets
 }
 
+// placementSquare returns the row and column where team must place its next tile
+func (s *state) placementSquare(team string) (int, int, error) {
+	token := s.tokens[team]
+	if token == nil {
+		return 0, 0, fmt.Errorf("%s has not placed a token", team)
+	}
+	if !s.playedFirstTurn[team] {
+		return token.Row, token.Col, nil
+	}
+	adj, err := token.getAdjacent(s.board.rows, s.board.columns)
+	if err != nil {
+		return 0, 0, err
+	}
+	return adj.Row, adj.Col, nil
+}
+
+// placements lists every distinct orientation of every tile in team's hand at the square team must place on
+// along with the teams each placement would eliminate
+func (s *state) placements(team string) []*PlacementTarget {
+	placements := make([]*PlacementTarget, 0)
+	if !s.alive[team] || s.placingTokens() {
+		return placements
+	}
+	row, col, err := s.placementSquare(team)
+	if err != nil || s.board.board[row][col] != nil {
+		return placements
+	}
+	for _, tile := range s.hands[team].hand {
+		rotated := tile.copy()
+		seen := make(map[[notchCount]notch]bool)
+		for i := 0; i < 4; i++ {
+			if key := rotated.shape.ends; !seen[key] {
+				seen[key] = true
+				eliminated := s.simulate(team, rotated, row, col)
+				placements = append(placements, &PlacementTarget{
+					Row:             row,
+					Column:          col,
+					Tile:            rotated.Edges,
+					Eliminated:      eliminated,
+					SelfEliminating: contains(eliminated, team),
+				})
+			}
+			rotated.RotateRight()
+		}
+	}
+	return placements
+}
+
+// legalPlacements is placements without the avoidable self eliminating placements when suicide moves are not allowed
+func (s *state) legalPlacements(team string) []*PlacementTarget {
+	placements := s.placements(team)
+	if !s.noSuicide {
+		return placements
+	}
+	legal := make([]*PlacementTarget, 0)
+	for _, placement := range placements {
+		if !placement.SelfEliminating {
+			legal = append(legal, placement)
+		}
+	}
+	if len(legal) == 0 {
+		return placements
+	}
+	return legal
+}
+
+// canSurvive is true if some placement keeps team on the board
+func (s *state) canSurvive(team string) bool {
+	for _, placement := range s.placements(team) {
+		if !placement.SelfEliminating {
+			return true
+		}
+	}
+	return false
+}
+
+// simulate places the tile on a copy of the board sharing its tiles returning the teams that would be eliminated
+func (s *state) simulate(team string, tile *tile, row, col int) []string {
+	tokens := make(map[string]*token)
+	for t, token := range s.tokens {
+		copied := *token
+		tokens[t] = &copied
+	}
+	playedFirstTurn := make(map[string]bool)
+	for t, played := range s.playedFirstTurn {
+		playedFirstTurn[t] = played
+	}
+	playedFirstTurn[team] = true
+	scratch := &state{
+		teams:           s.teams,
+		board:           s.board.share(),
+		tokens:          tokens,
+		playedFirstTurn: playedFirstTurn,
+		alive:           s.alive,
+		sharedTiles:     true,
+	}
+	scratch.board.board[row][col] = tile
+	looped := scratch.moveTokens()
+	eliminated := make([]string, 0)
+	for _, t := range s.teams {
+		token := scratch.tokens[t]
+		if s.alive[t] && scratch.playedFirstTurn[t] && token != nil && (contains(looped, t) ||
+			token.onEdge(s.board.rows, s.board.columns) || scratch.collided(scratch.tokens, t, token) || s.facingDaikaiju(token)) {
+			eliminated = append(eliminated, t)
+		}
+	}
+	return eliminated
+}
+
+// startingTokens lists every edge notch on a square where no token starts yet
+func (s *state) startingTokens() []*token {
+	tokens := make([]*token, 0)
+	for row := 0; row < s.board.rows; row++ {
+		for col := 0; col < s.board.columns; col++ {
+			occupied := false
+			for _, token := range s.tokens {
+				if token.Row == row && token.Col == col {
+					occupied = true
+				}
+			}
+			if occupied {
+				continue
+			}
+			for _, notch := range notchNames {
+				if t := newToken(row, col, string(notch)); t.onEdge(s.board.rows, s.board.columns) {
+					tokens = append(tokens, t)
+				}
+			}
+		}
+	}
+	return tokens
+}
+
 func (s *state) message() string {
 	message := fmt.Sprintf("%s must place a tile", s.turn)
+	if s.placingTokens() {
+		message = fmt.Sprintf("%s must place a token", s.turn)
+	}
 	if len(s.winners) > 0 {
 		switch s.variant {
-		case VariantClassic, VariantOpenTiles, VariantLongestPath, VariantMostCrossings:
+		case VariantClassic, VariantOpenTiles, VariantLongestPath, VariantMostCrossings, VariantSeas:
 			message = fmt.Sprintf("%s tie", strings.Join(s.winners, ", "))
 			if len(s.winners) == 1 {
 				message = fmt.Sprintf("%s wins", s.winners[0])
 			}
+		case VariantPartners:
+			winners := make([]string, 0)
+			for _, alliance := range s.alliancesOf(s.winners) {
+				winners = append(winners, strings.Join(alliance, " & "))
+			}
+			message = fmt.Sprintf("%s tie", strings.Join(winners, ", "))
+			if len(winners) == 1 {
+				message = fmt.Sprintf("%s win", winners[0])
+			}
 		case VariantSolo:
 			if len(s.winners) == 1 && s.winners[0] == "FAIL" {
 				message = "you saved 0 tokens"
@@ -559,11 +1193,36 @@ func (s *state) message() string {
 	return message
 }
 
-func uniqueRandomToken(tokens map[string]*token, random *rand.Rand) *token {
-	token := randomToken(random)
+// validAlliances checks that every team belongs to exactly one of at least two alliances
+func validAlliances(teams []string, alliances [][]string) error {
+	if len(alliances) < 2 {
+		return fmt.Errorf("at least two alliances are required")
+	}
+	members := flatten(alliances)
+	if duplicates(members) {
+		return fmt.Errorf("teams can only belong to one alliance")
+	}
+	if len(members) != len(teams) {
+		return fmt.Errorf("every team must belong to an alliance")
+	}
+	for _, member := range members {
+		if !contains(teams, member) {
+			return fmt.Errorf("alliance member %s is not a team", member)
+		}
+	}
+	for _, alliance := range alliances {
+		if len(alliance) == 0 {
+			return fmt.Errorf("alliances cannot be empty")
+		}
+	}
+	return nil
+}
+
+func uniqueRandomToken(tokens map[string]*token, random *rand.Rand, rows, columns int) *token {
+	token := randomToken(random, rows, columns)
 	for _, tok := range tokens {
 		if token.Row == tok.Row && token.Col == tok.Col {
-			return uniqueRandomToken(tokens, random)
+			return uniqueRandomToken(tokens, random, rows, columns)
 		}
 	}
 	return token
@@ -52,7 +52,195 @@ func Test_NewState(t *testing.T) {
 		},
 	}
 	for _, test := range testCases {
-		_, err := newState(test.teams, test.random, test.variant)
+		_, err := newState(test.teams, test.random, &TsuroMoreOptions{Variant: test.variant}, nil)
 		assert.Equal(t, err != nil, test.shouldErr, "ERROR: ", test.name)
 	}
 }
+
+func Test_DaikaijuDestroyTilesAndTokens(t *testing.T) {
+	state, err := newState([]string{"1", "2", "3"}, rand.New(rand.NewSource(123)), &TsuroMoreOptions{
+		Variant:      VariantSeas,
+		RandomTokens: true,
+	}, nil)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	state.daikaiju = []*daikaiju{{Row: 4, Col: 4}, {Row: 1, Col: 1}}
+	tile, _ := newTile("ABCDEFGH")
+	_ = state.board.Place(tile, 4, 5)
+	state.tokens["1"] = newToken(4, 5, "A")
+	state.playedFirstTurn["1"] = true
+
+	// rolls that do not wake the daikaiju leave them be
+	state.applyDaikaijuMove(&MoveDaikaijuActionDetails{Roll: 5, Directions: []int{}})
+	assert.Equal(t, 4, state.daikaiju[0].Col)
+
+	// first daikaiju moves east onto the tile and second swims north then off the board
+	state.applyDaikaijuMove(&MoveDaikaijuActionDetails{Roll: 7, Directions: []int{2, 1}})
+	assert.Equal(t, 5, state.daikaiju[0].Col)
+	assert.Nil(t, state.board.board[4][5])
+	assert.Len(t, state.destroyed, 1)
+	assert.False(t, state.alive["1"])
+	assert.Equal(t, 0, state.daikaiju[1].Row)
+
+	state.applyDaikaijuMove(&MoveDaikaijuActionDetails{Roll: 6, Directions: []int{5, 1}})
+	assert.Len(t, state.daikaiju, 1)
+}
+
+func Test_StateSeasWakeTiles(t *testing.T) {
+	s, err := newState([]string{TeamA, TeamB, "TeamC"}, rand.New(rand.NewSource(123)), &TsuroMoreOptions{
+		Variant:      VariantSeas,
+		RandomTokens: true,
+	}, nil)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	// every wake tile is dealt into the deck or a hand
+	dealt := make([]string, 0)
+	for _, tile := range s.deck.deck {
+		dealt = append(dealt, tile.Edges)
+	}
+	for _, hand := range s.hands {
+		for _, tile := range hand.hand {
+			dealt = append(dealt, tile.Edges)
+		}
+	}
+	assert.ElementsMatch(t, wakeTiles, dealt)
+
+	// the game ends with the surviving teams winning once the last wake tile is placed
+	s.deck.deck = nil
+	for _, hand := range s.hands {
+		hand.hand = nil
+	}
+	s.updateWinners(s.aliveTeams())
+	assert.ElementsMatch(t, []string{TeamA, TeamB, "TeamC"}, s.winners)
+
+	// a custom deck replaces the wake tiles
+	s, err = newState([]string{TeamA, TeamB}, rand.New(rand.NewSource(123)), &TsuroMoreOptions{
+		Variant: VariantSeas,
+		Tiles:   []string{"ABCDEFGH", "ABCDEFGH", "AHBGCDEF", "AHBGCDEF", "AHBGCDEF", "AHBGCDEF", "AHBGCDEF"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, len(s.deck.deck)+len(s.hands[TeamA].hand)+len(s.hands[TeamB].hand))
+}
+
+func Test_StateLoopedToken(t *testing.T) {
+	s, err := newState([]string{TeamA, TeamB}, rand.New(rand.NewSource(123)), &TsuroMoreOptions{Variant: VariantClassic}, nil)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	// join returns a tile whose path joins notches a and b
+	join := func(a, b notch) *tile {
+		for idx := range shapes {
+			for r := range shapes[idx] {
+				if shapes[idx][r].ends[a] == b {
+					tile, _ := newTile(shapes[idx][r].edges)
+					return tile
+				}
+			}
+		}
+		return nil
+	}
+	// the tiles left by a daikaiju close a loop through TeamA's token once the tile joining G and F is placed
+	s.board.board[1][1] = join(notchE, notchD)
+	s.board.board[1][1].travel(notchE, TeamA)
+	s.board.board[2][1] = join(notchB, notchC)
+	s.board.board[2][2] = join(notchA, notchH)
+	s.tokens[TeamA] = newToken(1, 1, "D")
+	s.tokens[TeamB] = newToken(0, 4, "A")
+	s.playedFirstTurn[TeamA] = true
+	s.turn = TeamA
+	loop := join(notchG, notchF)
+	s.hands[TeamA].hand = []*tile{loop}
+
+	placements := s.placements(TeamA)
+	selfEliminating := false
+	for _, placement := range placements {
+		placed, _ := newTile(placement.Tile)
+		if placed.destination(notchG) == notchF {
+			selfEliminating = placement.SelfEliminating
+		}
+	}
+	assert.True(t, selfEliminating)
+
+	events, err := s.PlaceTile(TeamA, loop.Edges, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, *newToken(1, 1, "D"), *s.tokens[TeamA])
+	assert.False(t, s.alive[TeamA])
+	assert.Equal(t, []string{TeamB}, s.winners)
+	looped := false
+	for _, event := range events {
+		looped = looped || (event.Type == EventLooped && event.Team == TeamA)
+	}
+	assert.True(t, looped)
+}
+
+func Test_StateSharedHandKnockedOut(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TsuroMoreOptions
+	}{
+		{name: "open tiles", options: TsuroMoreOptions{Variant: VariantOpenTiles}},
+		{name: "partners", options: TsuroMoreOptions{Variant: VariantPartners, Alliances: [][]string{{TeamA, "TeamC"}, {TeamB, "TeamD"}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := newState([]string{TeamA, TeamB, "TeamC", "TeamD"}, rand.New(rand.NewSource(123)), &test.options, nil)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			straight, _ := newTile(shapesByEnds[endsOf("AFBECHDG")].edges)
+			offEdge, _ := newTile(shapesByEnds[endsOf("HACBDEFG")].edges)
+			// TeamA's token faces the empty square at the top of the board where offEdge takes it off the edge
+			s.board.board[0][0] = straight
+			straight.travel(notchH, TeamA)
+			for idx, team := range s.teams {
+				s.tokens[team] = newToken(5, idx, "A")
+				s.playedFirstTurn[team] = true
+				tile := straight.copy()
+				tile.Paths = paths{}
+				tile.travel(notchF, team)
+				s.board.board[5][idx] = tile
+			}
+			s.tokens[TeamA] = newToken(0, 0, "C")
+			s.hands[TeamA].hand = append([]*tile{offEdge}, s.hands[TeamA].hand[1:]...)
+			deck := len(s.deck.deck)
+
+			_, err = s.PlaceTile(TeamA, offEdge.Edges, 0, 1)
+			assert.NoError(t, err)
+			assert.False(t, s.alive[TeamA])
+			// the hand stays with the teams still sharing it and is refilled for them
+			assert.Len(t, s.hands["TeamC"].hand, 3)
+			assert.Equal(t, deck-1, len(s.deck.deck))
+		})
+	}
+}
+
+func Test_StateSkipEmptyHands(t *testing.T) {
+	s, err := newState([]string{TeamA, TeamB, "TeamC"}, rand.New(rand.NewSource(123)), &TsuroMoreOptions{Variant: VariantClassic}, nil)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	straight, _ := newTile(shapesByEnds[endsOf("AFBECHDG")].edges)
+	for idx, team := range s.teams {
+		tile := straight.copy()
+		tile.travel(notchF, team)
+		s.board.board[2][idx*2] = tile
+		s.tokens[team] = newToken(2, idx*2, "A")
+		s.playedFirstTurn[team] = true
+	}
+	// TeamB played its last tile and the deck has run out
+	s.deck.deck = make([]*tile, 0)
+	s.hands[TeamB].Clear()
+
+	_, err = s.PlaceTile(TeamA, s.hands[TeamA].hand[0].Edges, 1, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, s.winners)
+	// TeamB has nothing to place so passes
+	assert.Equal(t, "TeamC", s.turn)
+}
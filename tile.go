@@ -1,6 +1,9 @@
 package go_tsuro
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 /*
 tile representation
@@ -13,67 +16,124 @@ tile representation
 	     F  E
 */
 type tile struct {
-	Edges string            // defines the tile
-	Paths map[string]string // map from path to team defining section team path
+	Edges string // defines the tile
+	Paths paths  // team that travelled each path of the tile
+	shape *shape // tile in the catalogue and its rotation
 }
 
+// paths holds the team that entered the tile by each notch from A to H so tiles are copied without allocating
+type paths [notchCount]string
+
 func newTile(edges string) (*tile, error) {
-	t := &tile{
-		Edges: edges,
-		Paths: make(map[string]string),
-	}
-	for i := 0; i < 4; i++ {
-		if contains(tiles, t.Edges) {
-			return &tile{
-				Edges: edges,
-				Paths: make(map[string]string),
-			}, nil
-		}
-		t.RotateRight()
+	shape, err := shapeOf(edges)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("edges %s are not a valid tile configuration", edges)
+	return &tile{Edges: edges, shape: shape}, nil
 }
 
 func (t *tile) GetDestination(start string) string {
-	for idx, char := range t.Edges {
-		if string(char) == start && idx%2 == 0 {
-			return string(t.Edges[idx+1])
-		} else if string(char) == start && idx%2 == 1 {
-			return string(t.Edges[idx-1])
-		}
+	if len(start) != 1 || start[0] < 'A' || start[0] > 'H' {
+		return ""
 	}
-	return ""
+	return t.destination(toNotch(start)).String()
+}
+
+// destination returns the notch the path starting at start ends at
+func (t *tile) destination(start notch) notch {
+	return t.shape.ends[start]
 }
 
 func (t *tile) RotateRight() {
-	transform := map[string]string{"A": "C", "B": "D", "C": "E", "D": "F", "E": "G", "F": "H", "G": "A", "H": "B"}
-	transformed := ""
-	for _, char := range t.Edges {
-		transformed += transform[string(char)]
-	}
-	t.Edges = transformed
+	t.shape = t.shape.rotated(1)
+	t.Edges = t.shape.edges
 }
 
 func (t *tile) RotateLeft() {
-	transform := map[string]string{"A": "G", "B": "H", "C": "A", "D": "B", "E": "C", "F": "D", "G": "E", "H": "F"}
-	transformed := ""
-	for _, char := range t.Edges {
-		transformed += transform[string(char)]
+	t.shape = t.shape.rotated(3)
+	t.Edges = t.shape.edges
+}
+
+func (t *tile) copy() *tile {
+	copied := *t
+	return &copied
+}
+
+// travel records team entering the tile by the entry notch
+func (t *tile) travel(entry notch, team string) {
+	t.Paths[entry] = team
+}
+
+// travelled is true if team has travelled along any path of the tile
+func (t *tile) travelled(team string) bool {
+	for _, travelled := range t.Paths {
+		if travelled == team {
+			return true
+		}
 	}
-	t.Edges = transformed
+	return false
 }
 
-func (t *tile) countCrossings(team string) int {
-	paths := make([]string, 0)
-	for path, t := range t.Paths {
-		if team == t {
-			paths = append(paths, path)
+// pathMap lists each travelled path as its entry and exit notches along with the team that travelled it
+func (t *tile) pathMap() map[string]string {
+	result := make(map[string]string)
+	for entry, team := range t.Paths {
+		if team != "" {
+			result[notch(entry).String()+t.destination(notch(entry)).String()] = team
+		}
+	}
+	return result
+}
+
+// jsonTile is how a tile is written to JSON with its travelled paths keyed by their entry and exit notches
+type jsonTile struct {
+	Edges string
+	Paths map[string]string
+}
+
+func (t tile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTile{
+		Edges: t.Edges,
+		Paths: t.pathMap(),
+	})
+}
+
+func (t *tile) UnmarshalJSON(data []byte) error {
+	var decoded jsonTile
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	decodedTile, err := newTile(decoded.Edges)
+	if err != nil {
+		return err
+	}
+	*t = *decodedTile
+	for path, team := range decoded.Paths {
+		if len(path) != 2 || path[0] < 'A' || path[0] > 'H' {
+			return fmt.Errorf("invalid path %s", path)
 		}
+		t.travel(toNotch(path), team)
 	}
+	return nil
+}
+
+// connections lists the destination of each notch from A to H which is the same for any spelling of the same orientation
+func (t *tile) connections() string {
+	connections := make([]byte, notchCount)
+	for n, end := range t.shape.ends {
+		connections[n] = notchNames[end]
+	}
+	return string(connections)
+}
+
+func (t *tile) countCrossings(team string) int {
 	count := 0
-	for i, path := range paths {
-		for j, otherPath := range paths {
-			if i != j && contains(crossing[path], otherPath) {
+	for a, travelled := range t.Paths {
+		if travelled != team {
+			continue
+		}
+		for c, other := range t.Paths {
+			if other == team && a != c && crosses(notch(a), t.destination(notch(a)), notch(c), t.destination(notch(c))) {
 				count++
 			}
 		}
@@ -82,14 +142,7 @@ func (t *tile) countCrossings(team string) int {
 }
 
 func (t *tile) equals(t2 *tile) bool {
-	copied, _ := newTile(t.Edges)
-	for i := 0; i < 4; i++ {
-		if copied.Edges == t2.Edges {
-			return true
-		}
-		copied.RotateRight()
-	}
-	return false
+	return t.shape.id == t2.shape.id
 }
 
 func (t *tile) in(list []*tile) bool {
@@ -2,6 +2,8 @@ package go_tsuro
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_NewTiel(t *testing.T) {
@@ -11,3 +13,41 @@ func Test_NewTiel(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func Test_Catalogue(t *testing.T) {
+	tiles := Catalogue()
+	assert.Len(t, tiles, 35)
+	orientations := make(map[string]bool)
+	symmetries := make(map[int]int)
+	for idx, tile := range tiles {
+		assert.Equal(t, idx+1, tile.ID)
+		symmetries[tile.Rotations]++
+		for rotation := 0; rotation < 4; rotation++ {
+			edges, err := TileEdges(tile.ID, rotation)
+			assert.NoError(t, err)
+			id, canonical, err := TileID(edges)
+			assert.NoError(t, err)
+			assert.Equal(t, tile.ID, id)
+			assert.Equal(t, rotation%tile.Rotations, canonical)
+			copied, _ := newTile(edges)
+			orientations[copied.connections()] = true
+		}
+	}
+	// every orientation of every tile has a single id and rotation
+	assert.Equal(t, map[int]int{1: 5, 2: 10, 4: 20}, symmetries)
+	assert.Len(t, orientations, 5*1+10*2+20*4)
+
+	// other spellings of the same orientation give the same id and rotation
+	id, rotation, err := TileID("BADCFEHG")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, []int{id, rotation})
+
+	for _, edges := range []string{"", "ABCDEFG", "AABBCCDD", "ABCDEFGI"} {
+		_, _, err := TileID(edges)
+		assert.Error(t, err, edges)
+	}
+	for _, args := range [][]int{{0, 0}, {36, 0}, {1, -1}, {1, 4}} {
+		_, err := TileEdges(args[0], args[1])
+		assert.Error(t, err, args)
+	}
+}
@@ -20,7 +20,7 @@ func newToken(row, col int, notch string) *token {
 	}
 }
 
-func randomToken(random *rand.Rand) *token {
+func randomToken(random *rand.Rand, rows, columns int) *token {
 	options := "ABCDEFGH"
 	notch := string(options[random.Intn(8)])                            // which notch to lie on
 	side := random.Intn(int(math.Min(float64(rows), float64(columns)))) // which side to lie on
@@ -50,45 +50,30 @@ func (t *token) equals(t2 *token) bool {
 	return false
 }
 
-func (t *token) collided(t2 *token) bool {
-	adjacent := map[string]string{"A": "F", "B": "E", "C": "H", "D": "G", "E": "B", "F": "A", "G": "D", "H": "C"}
-	switch t.Notch {
-	case "A", "B":
-		return t2.equals(&token{Row: t.Row - 1, Col: t.Col, Notch: adjacent[t.Notch]})
-	case "C", "D":
-		return t2.equals(&token{Row: t.Row, Col: t.Col + 1, Notch: adjacent[t.Notch]})
-	case "E", "F":
-		return t2.equals(&token{Row: t.Row + 1, Col: t.Col, Notch: adjacent[t.Notch]})
-	case "G", "H":
-		return t2.equals(&token{Row: t.Row, Col: t.Col - 1, Notch: adjacent[t.Notch]})
+// onEdge is true when the token sits on a notch facing off the board
+func (t *token) onEdge(rows, columns int) bool {
+	if t.Row < 0 || t.Col < 0 || t.Row >= rows || t.Col >= columns {
+		return false
 	}
-	return false
+	row, col, _ := t.neighbour()
+	return row < 0 || col < 0 || row >= rows || col >= columns
 }
 
-func (t *token) getAdjacent() (*token, error) {
-	adjacent := map[string]string{"A": "F", "B": "E", "C": "H", "D": "G", "E": "B", "F": "A", "G": "D", "H": "C"}
-	invalidErr := errors.New("invalid token")
-	switch t.Notch {
-	case "A", "B":
-		if t.Row <= 0 {
-			return nil, invalidErr
-		}
-		return &token{Row: t.Row - 1, Col: t.Col, Notch: adjacent[t.Notch]}, nil
-	case "C", "D":
-		if t.Col >= columns {
-			return nil, invalidErr
-		}
-		return &token{Row: t.Row, Col: t.Col + 1, Notch: adjacent[t.Notch]}, nil
-	case "E", "F":
-		if t.Row >= rows {
-			return nil, invalidErr
-		}
-		return &token{Row: t.Row + 1, Col: t.Col, Notch: adjacent[t.Notch]}, nil
-	case "G", "H":
-		if t.Col <= 0 {
-			return nil, invalidErr
-		}
-		return &token{Row: t.Row, Col: t.Col - 1, Notch: adjacent[t.Notch]}, nil
+// neighbour returns the square and notch on the neighbouring square touching the token's notch which may be off the board
+func (t *token) neighbour() (int, int, string) {
+	n := toNotch(t.Notch)
+	return t.Row + steps[n][0], t.Col + steps[n][1], facing[n].String()
+}
+
+func (t *token) collided(t2 *token) bool {
+	row, col, notch := t.neighbour()
+	return t2.Row == row && t2.Col == col && t2.Notch == notch
+}
+
+func (t *token) getAdjacent(rows, columns int) (*token, error) {
+	row, col, notch := t.neighbour()
+	if row < 0 || col < 0 || row >= rows || col >= columns {
+		return nil, errors.New("invalid token")
 	}
-	return nil, invalidErr
+	return &token{Row: row, Col: col, Notch: notch}, nil
 }
@@ -3,6 +3,7 @@ package go_tsuro
 import (
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -20,9 +21,27 @@ type Tsuro struct {
 	state   *state
 	actions []*bg.BoardGameAction
 	options *TsuroMoreOptions
+	source  *source // randomness shared by the deck, token placement, and daikaiju
+	timer   *timer
+	script  *script  // recorded randomness used in place of the seed in a secret seed game loaded without it
+	nonce   string   // random hex hashed with a secret seed and revealed along with it
+	rotated *rotated // tile rotated by the rotations at the end of the history when collapsing rotations
+}
+
+// rotated is the tile in hand turned by the rotations at the end of the history so rotations of different copies
+// of the same tile in a deck with duplicates are never collapsed together
+type rotated struct {
+	last  *bg.BoardGameAction // action at the end of the history once the rotations were recorded
+	index int                 // index of the rotated tile in the hand
+	count int                 // number of rotations at the end of the history that turned the tile
 }
 
 func NewTsuro(options *bg.BoardGameOptions) (*Tsuro, error) {
+	return newTsuro(options, nil)
+}
+
+// newTsuro creates a game replaying script in place of its seed's randomness if script is not nil
+func newTsuro(options *bg.BoardGameOptions, script *script) (*Tsuro, error) {
 	if len(options.Teams) < minTeams {
 		return nil, &bgerr.Error{
 			Err:    fmt.Errorf("at least %d teams required to create a game of %s", minTeams, key),
@@ -54,22 +73,82 @@ func NewTsuro(options *bg.BoardGameOptions) (*Tsuro, error) {
 			Status: bgerr.StatusInvalidOption,
 		}
 	}
-	state, err := newState(options.Teams, rand.New(rand.NewSource(details.Seed)), details.Variant)
+	if details.BoardSize == 0 && details.Variant == VariantSeas {
+		details.BoardSize = seasBoardSize
+	} else if details.BoardSize == 0 {
+		details.BoardSize = defaultBoardSize
+	} else if details.Variant == VariantSeas && details.BoardSize != seasBoardSize {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("%s must be played on a %dx%d board", VariantSeas, seasBoardSize, seasBoardSize),
+			Status: bgerr.StatusInvalidOption,
+		}
+	} else if details.BoardSize < minBoardSize || details.BoardSize > maxBoardSize {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("board size must be between %d and %d", minBoardSize, maxBoardSize),
+			Status: bgerr.StatusInvalidOption,
+		}
+	}
+	if details.TurnTime < 0 || details.GameTime < 0 {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("turn and game time cannot be negative"),
+			Status: bgerr.StatusInvalidOption,
+		}
+	}
+	if details.TimeoutMove == "" {
+		details.TimeoutMove = TimeoutRandom
+	} else if !contains(timeoutMoves, details.TimeoutMove) {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("invalid timeout move %s", details.TimeoutMove),
+			Status: bgerr.StatusInvalidOption,
+		}
+	}
+	var nonce string
+	if details.SecretSeed {
+		var err error
+		if nonce, err = newNonce(); err != nil {
+			return nil, &bgerr.Error{
+				Err:    err,
+				Status: bgerr.StatusInvalidOption,
+			}
+		}
+	}
+	source := newSource(details.Seed)
+	state, err := newState(options.Teams, rand.New(source), &details, script)
 	if err != nil {
 		return nil, &bgerr.Error{
 			Err:    err,
 			Status: bgerr.StatusInvalidOption,
 		}
 	}
-	return &Tsuro{
+	t := &Tsuro{
 		state:   state,
 		actions: make([]*bg.BoardGameAction, 0),
 		options: &details,
-	}, nil
+		source:  source,
+		script:  script,
+		nonce:   nonce,
+	}
+	t.SetClock(systemClock{})
+	return t, nil
 }
 
 func (t *Tsuro) Do(action *bg.BoardGameAction) error {
-	if len(t.state.winners) > 0 {
+	if err := t.Tick(); err != nil {
+		return err
+	}
+	turn := t.state.turn
+	if err := t.do(action); err != nil {
+		return err
+	}
+	if turn != t.state.turn || action.ActionType == ActionPlaceTile || action.ActionType == ActionPlaceToken {
+		t.endTurn(turn)
+	}
+	t.mark()
+	return nil
+}
+
+func (t *Tsuro) do(action *bg.BoardGameAction) error {
+	if len(t.state.winners) > 0 && action.ActionType != ActionUndo {
 		return &bgerr.Error{
 			Err:    fmt.Errorf("game already over"),
 			Status: bgerr.StatusGameOver,
@@ -84,9 +163,11 @@ func (t *Tsuro) Do(action *bg.BoardGameAction) error {
 				Status: bgerr.StatusInvalidActionDetails,
 			}
 		}
-		if err := t.state.RotateTileRight(action.Team, details.Tile); err != nil {
+		idx, err := t.state.RotateTileRight(action.Team, details.Tile, details.index())
+		if err != nil {
 			return err
 		}
+		t.recordRotation(action.Team, ActionRotateTileRight, details.Tile, idx, details.Index)
 	case ActionRotateTileLeft:
 		var details RotateTileActionDetails
 		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
@@ -95,9 +176,23 @@ func (t *Tsuro) Do(action *bg.BoardGameAction) error {
 				Status: bgerr.StatusInvalidActionDetails,
 			}
 		}
-		if err := t.state.RotateTileLeft(action.Team, details.Tile); err != nil {
+		idx, err := t.state.RotateTileLeft(action.Team, details.Tile, details.index())
+		if err != nil {
+			return err
+		}
+		t.recordRotation(action.Team, ActionRotateTileLeft, details.Tile, idx, details.Index)
+	case ActionPlaceToken:
+		var details PlaceTokenActionDetails
+		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+			return &bgerr.Error{
+				Err:    err,
+				Status: bgerr.StatusInvalidActionDetails,
+			}
+		}
+		if err := t.state.PlaceToken(action.Team, details.Row, details.Column, details.Notch); err != nil {
 			return err
 		}
+		t.actions = append(t.actions, action)
 	case ActionPlaceTile:
 		var details PlaceTileActionDetails
 		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
@@ -106,7 +201,19 @@ func (t *Tsuro) Do(action *bg.BoardGameAction) error {
 				Status: bgerr.StatusInvalidActionDetails,
 			}
 		}
-		if err := t.state.PlaceTile(action.Team, details.Tile, details.Row, details.Column); err != nil {
+		if _, err := t.state.PlaceTile(action.Team, details.Tile, details.Row, details.Column); err != nil {
+			return err
+		}
+		t.actions = append(t.actions, action)
+		if t.state.daikaijuMove != nil {
+			t.actions = append(t.actions, &bg.BoardGameAction{
+				Team:        action.Team,
+				ActionType:  ActionMoveDaikaiju,
+				MoreDetails: *t.state.daikaijuMove,
+			})
+		}
+	case ActionResign:
+		if err := t.state.Resign(action.Team); err != nil {
 			return err
 		}
 		t.actions = append(t.actions, action)
@@ -122,6 +229,46 @@ func (t *Tsuro) Do(action *bg.BoardGameAction) error {
 			return err
 		}
 		t.actions = append(t.actions, action)
+	case ActionUndo:
+		var details UndoActionDetails
+		if err := mapstructure.Decode(action.MoreDetails, &details); err != nil {
+			return &bgerr.Error{
+				Err:    err,
+				Status: bgerr.StatusInvalidActionDetails,
+			}
+		}
+		if !contains(t.state.teams, action.Team) {
+			return &bgerr.Error{
+				Err:    fmt.Errorf("%s not a valid team", action.Team),
+				Status: bgerr.StatusUnknownTeam,
+			}
+		}
+		if details.Actions == 0 {
+			details.Actions = 1
+		}
+		// teams may only take back their own actions made since another team last acted
+		cut, _ := t.cut(details.Actions)
+		for _, undone := range t.actions[cut:] {
+			if undone.Team != action.Team {
+				return &bgerr.Error{
+					Err:    fmt.Errorf("%s cannot undo an action by %s", action.Team, undone.Team),
+					Status: bgerr.StatusInvalidAction,
+				}
+			}
+		}
+		if err := t.Undo(details.Actions); err != nil {
+			return err
+		}
+	case ActionMoveDaikaiju:
+		return &bgerr.Error{
+			Err:    fmt.Errorf("daikaiju move automatically after each placement"),
+			Status: bgerr.StatusInvalidAction,
+		}
+	case ActionTimeout:
+		return &bgerr.Error{
+			Err:    fmt.Errorf("timeouts happen automatically when a team runs out of time"),
+			Status: bgerr.StatusInvalidAction,
+		}
 	default:
 		return &bgerr.Error{
 			Err:    fmt.Errorf("cannot process action type %s", action.ActionType),
@@ -131,75 +278,268 @@ func (t *Tsuro) Do(action *bg.BoardGameAction) error {
 	return nil
 }
 
-func (t *Tsuro) GetSnapshot(team ...string) (*bg.BoardGameSnapshot, error) {
-	if len(team) > 1 {
+// recordRotation adds the rotation of the tile at index in team's hand to the action history keeping at, the index the team gave if any
+// when collapsing rotations the trailing run of rotations of the same tile by the same team is replaced by its net rotation
+func (t *Tsuro) recordRotation(team, actionType, edges string, index int, at *int) {
+	if !t.options.CollapseRotations {
+		t.actions = append(t.actions, &bg.BoardGameAction{
+			Team:        team,
+			ActionType:  actionType,
+			MoreDetails: RotateTileActionDetails{Tile: edges, Index: at},
+		})
+		return
+	}
+	start := edges
+	net := 1
+	if actionType == ActionRotateTileLeft {
+		net = 3
+	}
+	run := len(t.actions)
+	if r := t.rotated; r != nil && r.index == index && run > 0 && t.actions[run-1] == r.last {
+		for ; run > len(t.actions)-r.count; run-- {
+			action := t.actions[run-1]
+			if action.Team != team {
+				break
+			}
+			var details RotateTileActionDetails
+			_ = mapstructure.Decode(action.MoreDetails, &details)
+			start = details.Tile
+			if action.ActionType == ActionRotateTileRight {
+				net++
+			} else {
+				net += 3
+			}
+		}
+	}
+	t.actions = t.actions[:run]
+	t.forget(run)
+	switch net % 4 {
+	case 1:
+		t.actions = append(t.actions, &bg.BoardGameAction{
+			Team:        team,
+			ActionType:  ActionRotateTileRight,
+			MoreDetails: RotateTileActionDetails{Tile: start, Index: at},
+		})
+	case 2:
+		first, _ := newTile(start)
+		first.RotateRight()
+		t.actions = append(t.actions, &bg.BoardGameAction{
+			Team:        team,
+			ActionType:  ActionRotateTileRight,
+			MoreDetails: RotateTileActionDetails{Tile: start, Index: at},
+		}, &bg.BoardGameAction{
+			Team:        team,
+			ActionType:  ActionRotateTileRight,
+			MoreDetails: RotateTileActionDetails{Tile: first.Edges, Index: at},
+		})
+	case 3:
+		t.actions = append(t.actions, &bg.BoardGameAction{
+			Team:        team,
+			ActionType:  ActionRotateTileLeft,
+			MoreDetails: RotateTileActionDetails{Tile: start, Index: at},
+		})
+	}
+	t.rotated = nil
+	if count := len(t.actions) - run; count > 0 {
+		t.rotated = &rotated{last: t.actions[len(t.actions)-1], index: index, count: count}
+	}
+}
+
+// Placements lists every distinct orientation of every tile in team's hand that team could place right now
+// along with which teams each placement would eliminate, leaving out avoidable self eliminations when NoSuicide is set
+func (t *Tsuro) Placements(team string) ([]*PlacementTarget, error) {
+	if !contains(t.state.teams, team) {
 		return nil, &bgerr.Error{
-			Err:    fmt.Errorf("get snapshot requires zero or one team"),
-			Status: bgerr.StatusTooManyTeams,
+			Err:    fmt.Errorf("%s not a valid team", team),
+			Status: bgerr.StatusUnknownTeam,
 		}
 	}
-	hands := make(map[string][]*tile)
-	for t, hand := range t.state.hands {
-		if len(team) == 0 {
-			hands[t] = hand.hand
-		} else {
-			if team[0] == t {
-				hands[t] = hand.hand
-			}
+	if len(t.state.winners) > 0 {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("game already over"),
+			Status: bgerr.StatusGameOver,
+		}
+	}
+	return t.state.legalPlacements(team), nil
+}
+
+// Path returns the segments team's token has travelled in the order it travelled them
+func (t *Tsuro) Path(team string) ([]*PathSegment, error) {
+	if !contains(t.state.teams, team) {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("%s not a valid team", team),
+			Status: bgerr.StatusUnknownTeam,
+		}
+	}
+	path := make([]*PathSegment, 0, len(t.state.paths[team]))
+	for _, segment := range t.state.paths[team] {
+		copied := *segment
+		path = append(path, &copied)
+	}
+	return path, nil
+}
+
+// Undo takes back the last n actions by rebuilding the game from its seed and replaying the remaining actions
+// actions performed automatically such as daikaiju moves are taken back along with the action that caused them
+func (t *Tsuro) Undo(n int) error {
+	cut, undone := t.cut(n)
+	if n <= 0 || undone < n {
+		return &bgerr.Error{
+			Err:    fmt.Errorf("cannot undo %d of %d actions", n, undone),
+			Status: bgerr.StatusInvalidActionDetails,
+		}
+	}
+	game, err := t.replay(t.actions[:cut])
+	if err != nil {
+		return err
+	}
+	turn := t.state.turn
+	game.state.observers = t.state.observers
+	t.state = game.state
+	t.actions = game.actions
+	t.rotated = game.rotated
+	t.forget(len(t.actions))
+	if t.state.turn != turn {
+		t.state.notifyTurn()
+	}
+	return nil
+}
+
+// cut finds where the history is cut to take back the last n actions along with any automatic actions they caused
+// returning the number of actions that can be taken back when there are fewer than n
+func (t *Tsuro) cut(n int) (int, int) {
+	cut, undone := len(t.actions), 0
+	for ; undone < n && cut > 0; cut-- {
+		if !automatic(t.actions[cut-1].ActionType) {
+			undone++
 		}
 	}
-	var points map[string]int
-	if t.state.variant == VariantLongestPath || t.state.variant == VariantMostCrossings {
-		points = t.state.points
+	return cut, undone
+}
+
+// StateAt returns a read only snapshot of the game as it was after the first n actions
+func (t *Tsuro) StateAt(n int, team ...string) (*bg.BoardGameSnapshot, error) {
+	if n < 0 || n > len(t.actions) {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("action %d out of range of %d actions", n, len(t.actions)),
+			Status: bgerr.StatusInvalidActionDetails,
+		}
+	}
+	game, err := t.replay(t.actions[:n])
+	if err != nil {
+		return nil, err
+	}
+	return game.GetSnapshot(team...)
+}
+
+// replay builds a new game with the same options and performs each action on it
+func (t *Tsuro) replay(actions []*bg.BoardGameAction) (*Tsuro, error) {
+	game, err := newTsuro(&bg.BoardGameOptions{
+		Teams:       append([]string{}, t.state.teams...),
+		MoreOptions: *t.options,
+	}, t.replayScript())
+	if err != nil {
+		return nil, err
+	}
+	game.nonce = t.nonce
+	for _, action := range actions {
+		if automatic(action.ActionType) {
+			continue
+		}
+		if action.ActionType == ActionTimeout {
+			if err := game.replayTimeout(action); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := game.Do(action); err != nil {
+			return nil, err
+		}
 	}
-	details := TsuroSnapshotData{
-		Board:          t.state.board.board,
-		TilesRemaining: len(t.state.deck.deck),
-		Hands:          hands,
-		Tokens:         t.state.tokens,
-		Dragon:         t.state.dragon,
-		Variant:        t.state.variant,
-		Points:         points,
+	return game, nil
+}
+
+// automatic is true for actions the game performs on its own which are recorded but cannot be done by a team
+func automatic(actionType string) bool {
+	return actionType == ActionMoveDaikaiju
+}
+
+// GetSnapshot returns the omniscient view with no team or the view of the given team
+func (t *Tsuro) GetSnapshot(team ...string) (*bg.BoardGameSnapshot, error) {
+	if len(team) > 1 {
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("get snapshot requires zero or one team"),
+			Status: bgerr.StatusTooManyTeams,
+		}
 	}
-	var targets []*bg.BoardGameAction
-	if len(t.state.winners) == 0 {
-		targets = t.state.targets(team...)
+	if len(team) == 0 {
+		return t.GetView(View{Mode: ViewOmniscient})
 	}
-	return &bg.BoardGameSnapshot{
-		Turn:     t.state.turn,
-		Teams:    t.state.teams,
-		Winners:  t.state.winners,
-		MoreData: details,
-		Targets:  targets,
-		Actions:  t.actions,
-		Message:  t.state.message(),
-	}, nil
+	return t.GetView(View{Mode: ViewPlayer, Team: team[0]})
 }
 
 func (t *Tsuro) GetBGN() *bgn.Game {
 	tags := map[string]string{
-		"Game":    key,
-		"Teams":   strings.Join(t.state.teams, ", "),
-		"Variant": t.options.Variant,
-		"Seed":    fmt.Sprintf("%d", t.options.Seed),
+		"Game":      key,
+		"Teams":     strings.Join(t.state.teams, ", "),
+		"Variant":   t.options.Variant,
+		"Seed":      fmt.Sprintf("%d", t.options.Seed),
+		"BoardSize": fmt.Sprintf("%d", t.options.BoardSize),
 	}
-	actions := make([]bgn.Action, 0)
-	for _, action := range t.actions {
-		bgnAction := bgn.Action{
-			TeamIndex: indexOf(t.state.teams, action.Team),
-			ActionKey: rune(actionToNotation[action.ActionType][0]),
-		}
-		switch action.ActionType {
-		case ActionPlaceTile:
-			var details PlaceTileActionDetails
-			_ = mapstructure.Decode(action.MoreDetails, &details)
-			bgnAction.Details = details.encodeBGN()
-		case bg.ActionSetWinners:
-			var details bg.SetWinnersActionDetails
-			_ = mapstructure.Decode(action.MoreDetails, &details)
-			bgnAction.Details, _ = details.EncodeBGN(t.state.teams)
+	// always written as games recorded before tokens could be placed by hand have no tag and placed them at random
+	tags["RandomTokens"] = strconv.FormatBool(t.options.RandomTokens)
+	if t.options.CollapseRotations {
+		tags["CollapseRotations"] = "true"
+	}
+	if t.options.NoSuicide {
+		tags["NoSuicide"] = "true"
+	}
+	if t.options.TurnTime > 0 {
+		tags["TurnTime"] = t.options.TurnTime.String()
+	}
+	if t.options.GameTime > 0 {
+		tags["GameTime"] = t.options.GameTime.String()
+	}
+	if t.options.TurnTime > 0 || t.options.GameTime > 0 {
+		tags["TimeoutMove"] = t.options.TimeoutMove
+	}
+	if len(t.options.Alliances) > 0 {
+		alliances := make([]string, 0)
+		for _, alliance := range t.options.Alliances {
+			alliances = append(alliances, strings.Join(alliance, ", "))
 		}
-		actions = append(actions, bgnAction)
+		tags["Alliances"] = strings.Join(alliances, "; ")
+	}
+	if len(t.options.Tiles) > 0 {
+		tags["Tiles"] = strings.Join(t.options.Tiles, ", ")
+	}
+	if t.options.SecretSeed {
+		// the seed decides every draw and the draws show every hand so both are only revealed once the game is over
+		// until then only the draws of tiles that have been placed are shown
+		over := len(t.state.winners) > 0
+		if t.script != nil || !over {
+			delete(tags, "Seed")
+		} else if t.nonce != "" {
+			tags["SeedNonce"] = t.nonce
+		}
+		tags["SecretSeed"] = "true"
+		tags["SeedHash"] = t.commitment()
+		if over {
+			tags["Draws"] = strings.Join(t.state.deck.drawn, ", ")
+		} else {
+			tags["Draws"] = strings.Join(t.publicDraws(), ", ")
+		}
+		tokens, daikaiju := t.starting()
+		if t.options.RandomTokens {
+			tags["Tokens"] = encodeTokensTag(t.state.teams, tokens)
+		}
+		if len(daikaiju) > 0 {
+			tags["Daikaiju"] = encodeDaikaijuTag(daikaiju)
+		}
+	}
+	actions := make([]bgn.Action, 0)
+	for _, action := range t.publicActions() {
+		actions = append(actions, encodeActionBGN(t.state.teams, action))
 	}
 	return &bgn.Game{
 		Tags:    tags,
@@ -1,10 +1,12 @@
 package go_tsuro
 
 import (
+	"math/rand"
 	"testing"
 	"time"
 
 	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgn"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,17 +15,21 @@ const (
 	TeamB = "TeamB"
 )
 
-func Test_TsuroSmoke(t *testing.T) {
-	tsuro, err := NewTsuro(&bg.BoardGameOptions{
-		Teams: []string{TeamA, TeamB},
-		MoreOptions: TsuroMoreOptions{
-			Seed: time.Now().UnixNano(),
-		},
-	})
+// newTestTsuro creates a game of Tsuro between teams with options stopping the test if it cannot be created
+func newTestTsuro(t testing.TB, teams []string, options TsuroMoreOptions) *Tsuro {
+	t.Helper()
+	game, err := NewTsuro(&bg.BoardGameOptions{Teams: teams, MoreOptions: options})
 	if err != nil {
-		t.Error(err)
-		t.FailNow()
+		t.Fatal(err)
 	}
+	return game
+}
+
+func Test_TsuroSmoke(t *testing.T) {
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{
+		Seed:         time.Now().UnixNano(),
+		RandomTokens: true,
+	})
 
 	edges := "ABCDEFGH"
 	rotated := "CDEFGHAB"
@@ -68,3 +74,938 @@ func Test_TsuroSmoke(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func Test_TsuroPlaceToken(t *testing.T) {
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{
+		Seed: 123,
+	})
+
+	// tiles cannot be placed before every token has been placed
+	err := tsuro.Do(&bg.BoardGameAction{
+		Team:       TeamA,
+		ActionType: ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{
+			Row:    0,
+			Column: 0,
+			Tile:   tsuro.state.hands[TeamA].hand[0].Edges,
+		},
+	})
+	assert.Error(t, err)
+
+	// notch must face off the board
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:        TeamA,
+		ActionType:  ActionPlaceToken,
+		MoreDetails: PlaceTokenActionDetails{Row: 0, Column: 0, Notch: "E"},
+	})
+	assert.Error(t, err)
+
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:        TeamA,
+		ActionType:  ActionPlaceToken,
+		MoreDetails: PlaceTokenActionDetails{Row: 0, Column: 0, Notch: "A"},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// square is already taken by TeamA
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:        TeamB,
+		ActionType:  ActionPlaceToken,
+		MoreDetails: PlaceTokenActionDetails{Row: 0, Column: 0, Notch: "H"},
+	})
+	assert.Error(t, err)
+
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:        TeamB,
+		ActionType:  ActionPlaceToken,
+		MoreDetails: PlaceTokenActionDetails{Row: 5, Column: 5, Notch: "E"},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, TeamA, tsuro.state.turn)
+	assert.False(t, tsuro.state.placingTokens())
+
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:       TeamA,
+		ActionType: ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{
+			Row:    0,
+			Column: 0,
+			Tile:   tsuro.state.hands[TeamA].hand[0].Edges,
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	builder := Builder{}
+	loaded, err := builder.Load(tsuro.GetBGN())
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, tsuro.state.tokens, loaded.(*Tsuro).state.tokens)
+	assert.Equal(t, "false", tsuro.GetBGN().Tags["RandomTokens"])
+
+	// games recorded before tokens could be placed by hand have no tag and placed them at random
+	old := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{Seed: 123, RandomTokens: true})
+	targets, _ := old.Placements(TeamA)
+	err = old.Do(&bg.BoardGameAction{
+		Team:        TeamA,
+		ActionType:  ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{Row: targets[0].Row, Column: targets[0].Column, Tile: targets[0].Tile},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	record := old.GetBGN()
+	delete(record.Tags, "RandomTokens")
+	loaded, err = builder.Load(record)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	expected, _ := old.GetSnapshot()
+	actual, _ := loaded.GetSnapshot()
+	assert.Equal(t, expected, actual)
+}
+
+func Test_TsuroUndo(t *testing.T) {
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{
+		Seed:         123,
+		RandomTokens: true,
+	})
+	token := tsuro.state.tokens[TeamA]
+	hand := make([]string, 0)
+	for _, tile := range tsuro.state.hands[TeamA].hand {
+		hand = append(hand, tile.Edges)
+	}
+
+	err := tsuro.Do(&bg.BoardGameAction{
+		Team:       TeamA,
+		ActionType: ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{
+			Row:    token.Row,
+			Column: token.Col,
+			Tile:   hand[0],
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, 1, tsuro.state.board.getTileCount())
+
+	// the other team cannot take back TeamA's placement
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:       TeamB,
+		ActionType: ActionUndo,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, tsuro.state.board.getTileCount())
+
+	// undo through Do by the team that placed the tile
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:       TeamA,
+		ActionType: ActionUndo,
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, 0, tsuro.state.board.getTileCount())
+	assert.Equal(t, TeamA, tsuro.state.turn)
+	assert.Len(t, tsuro.actions, 0)
+	for idx, tile := range tsuro.state.hands[TeamA].hand {
+		assert.Equal(t, hand[idx], tile.Edges)
+	}
+
+	// nothing left to undo
+	assert.Error(t, tsuro.Undo(1))
+
+	err = tsuro.Do(&bg.BoardGameAction{
+		Team:       TeamA,
+		ActionType: ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{
+			Row:    token.Row,
+			Column: token.Col,
+			Tile:   hand[1],
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	snapshot, err := tsuro.StateAt(0)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, TeamA, snapshot.Turn)
+	assert.Len(t, snapshot.Actions, 0)
+	assert.Equal(t, 1, tsuro.state.board.getTileCount())
+}
+
+func Test_TsuroRecordRotations(t *testing.T) {
+	rotate := func(tsuro *Tsuro, actionType string) {
+		err := tsuro.Do(&bg.BoardGameAction{
+			Team:       TeamA,
+			ActionType: actionType,
+			MoreDetails: RotateTileActionDetails{
+				Tile: tsuro.state.hands[TeamA].hand[0].Edges,
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+	}
+	testCases := []struct {
+		name     string
+		collapse bool
+		rotate   []string
+		expected []string
+	}{
+		{
+			name:     "rotations are recorded",
+			rotate:   []string{ActionRotateTileRight, ActionRotateTileRight, ActionRotateTileLeft},
+			expected: []string{ActionRotateTileRight, ActionRotateTileRight, ActionRotateTileLeft},
+		},
+		{
+			name:     "opposite rotations collapse to nothing",
+			collapse: true,
+			rotate:   []string{ActionRotateTileRight, ActionRotateTileLeft},
+			expected: []string{},
+		},
+		{
+			name:     "three rights collapse to one left",
+			collapse: true,
+			rotate:   []string{ActionRotateTileRight, ActionRotateTileRight, ActionRotateTileRight},
+			expected: []string{ActionRotateTileLeft},
+		},
+		{
+			name:     "two lefts collapse to two rights",
+			collapse: true,
+			rotate:   []string{ActionRotateTileLeft, ActionRotateTileRight, ActionRotateTileLeft, ActionRotateTileLeft},
+			expected: []string{ActionRotateTileRight, ActionRotateTileRight},
+		},
+	}
+	for _, test := range testCases {
+		tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{
+			Seed:              123,
+			RandomTokens:      true,
+			CollapseRotations: test.collapse,
+		})
+		for _, actionType := range test.rotate {
+			rotate(tsuro, actionType)
+		}
+		actual := make([]string, 0)
+		for _, action := range tsuro.actions {
+			actual = append(actual, action.ActionType)
+		}
+		assert.Equal(t, test.expected, actual, test.name)
+
+		builder := Builder{}
+		loaded, err := builder.Load(tsuro.GetBGN())
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		assert.Equal(t, tsuro.state.hands[TeamA].hand[0].Edges, loaded.(*Tsuro).state.hands[TeamA].hand[0].Edges, test.name)
+		assert.Len(t, loaded.(*Tsuro).actions, len(test.expected), test.name)
+	}
+}
+
+func Test_TsuroCollapseDuplicateRotations(t *testing.T) {
+	var edges string
+	for _, tile := range Catalogue() {
+		if tile.Rotations == 4 {
+			edges = tile.Edges
+			break
+		}
+	}
+	set := make([]string, 8)
+	for idx := range set {
+		set[idx] = edges
+	}
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{
+		Seed:              123,
+		RandomTokens:      true,
+		CollapseRotations: true,
+		Tiles:             set,
+	})
+	// each rotation turns a different copy of the same tile as the first copy no longer has the named orientation
+	for i := 0; i < 2; i++ {
+		err := tsuro.Do(&bg.BoardGameAction{
+			Team:        TeamA,
+			ActionType:  ActionRotateTileRight,
+			MoreDetails: RotateTileActionDetails{Tile: edges},
+		})
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+	}
+	rotated, _ := TileEdges(tsuro.state.hands[TeamA].hand[0].shape.id, 1)
+	hand := make([]string, 0)
+	for _, tile := range tsuro.state.hands[TeamA].hand {
+		hand = append(hand, tile.Edges)
+	}
+	assert.Equal(t, []string{rotated, rotated, edges}, hand)
+	assert.Len(t, tsuro.actions, 2)
+	for _, action := range tsuro.actions {
+		assert.Equal(t, RotateTileActionDetails{Tile: edges}, action.MoreDetails)
+	}
+
+	builder := Builder{}
+	loaded, err := builder.Load(tsuro.GetBGN())
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	expected, _ := tsuro.GetSnapshot(TeamA)
+	actual, _ := loaded.GetSnapshot(TeamA)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_TsuroBoardSize(t *testing.T) {
+	_, err := NewTsuro(&bg.BoardGameOptions{
+		Teams: []string{TeamA, TeamB},
+		MoreOptions: TsuroMoreOptions{
+			BoardSize: minBoardSize - 1,
+		},
+	})
+	assert.Error(t, err)
+
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{
+		Seed:         123,
+		RandomTokens: true,
+		BoardSize:    4,
+	})
+	assert.Len(t, tsuro.state.board.board, 4)
+	assert.Len(t, tsuro.state.board.board[0], 4)
+	for _, token := range tsuro.state.tokens {
+		assert.True(t, token.onEdge(4, 4))
+	}
+
+	// fill all but one square so the board counts as full
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			if row == 3 && col == 3 {
+				continue
+			}
+			tile, _ := newTile(tiles[0])
+			_ = tsuro.state.board.Place(tile, row, col)
+		}
+	}
+	assert.True(t, tsuro.state.allTilesPlaced())
+
+	builder := Builder{}
+	loaded, err := builder.Load(tsuro.GetBGN())
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	assert.Equal(t, 4, loaded.(*Tsuro).state.board.rows)
+}
+
+func Test_TsuroPlacements(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		tsuro := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, TsuroMoreOptions{
+			Seed:         seed,
+			RandomTokens: true,
+		})
+		for len(tsuro.state.winners) == 0 {
+			team := tsuro.state.turn
+			placements, err := tsuro.Placements(team)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			assert.NotEmpty(t, placements)
+			// every placement must eliminate exactly the teams it says it does
+			for _, placement := range placements {
+				alive := tsuro.state.aliveCount()
+				err := tsuro.Do(&bg.BoardGameAction{
+					Team:       team,
+					ActionType: ActionPlaceTile,
+					MoreDetails: PlaceTileActionDetails{
+						Row:    placement.Row,
+						Column: placement.Column,
+						Tile:   placement.Tile,
+					},
+				})
+				if err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+				assert.Equal(t, placement.SelfEliminating, !tsuro.state.alive[team])
+				assert.Equal(t, alive-len(placement.Eliminated), tsuro.state.aliveCount())
+				_ = tsuro.Undo(1)
+			}
+			last := placements[len(placements)-1]
+			_ = tsuro.Do(&bg.BoardGameAction{
+				Team:       team,
+				ActionType: ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{
+					Row:    last.Row,
+					Column: last.Column,
+					Tile:   last.Tile,
+				},
+			})
+		}
+	}
+
+	// a tile with the same paths in every orientation only has one placement
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{RandomTokens: true})
+	symmetric, _ := newTile("ABCDEFGH")
+	tsuro.state.hands[TeamA].hand = []*tile{symmetric}
+	placements, _ := tsuro.Placements(TeamA)
+	assert.Len(t, placements, 1)
+}
+
+func Test_TsuroNoSuicide(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		tsuro := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{
+			Seed:         seed,
+			RandomTokens: true,
+			NoSuicide:    true,
+		})
+		var suicide *PlacementTarget
+		safe := 0
+		for _, placement := range tsuro.state.placements(TeamA) {
+			if placement.SelfEliminating {
+				suicide = placement
+			} else {
+				safe++
+			}
+		}
+		if suicide == nil || safe == 0 {
+			continue
+		}
+		legal, _ := tsuro.Placements(TeamA)
+		assert.Len(t, legal, safe)
+		// targets list every safe orientation of every tile in hand
+		snapshot, _ := tsuro.GetSnapshot(TeamA)
+		placed := make([]string, 0)
+		for _, target := range snapshot.Targets.([]*bg.BoardGameAction) {
+			if target.ActionType == ActionPlaceTile {
+				tile, _ := newTile(target.MoreDetails.(PlaceTileActionDetails).Tile)
+				assert.NotContains(t, tsuro.state.simulate(TeamA, tile, suicide.Row, suicide.Column), TeamA)
+				placed = append(placed, tile.Edges)
+			}
+		}
+		expected := make([]string, 0)
+		for _, placement := range legal {
+			expected = append(expected, placement.Tile)
+		}
+		assert.Equal(t, expected, placed)
+		err := tsuro.Do(&bg.BoardGameAction{
+			Team:       TeamA,
+			ActionType: ActionPlaceTile,
+			MoreDetails: PlaceTileActionDetails{
+				Row:    suicide.Row,
+				Column: suicide.Column,
+				Tile:   suicide.Tile,
+			},
+		})
+		assert.Error(t, err)
+
+		// the same move is allowed without the rule
+		tsuro.state.noSuicide = false
+		err = tsuro.Do(&bg.BoardGameAction{
+			Team:       TeamA,
+			ActionType: ActionPlaceTile,
+			MoreDetails: PlaceTileActionDetails{
+				Row:    suicide.Row,
+				Column: suicide.Column,
+				Tile:   suicide.Tile,
+			},
+		})
+		assert.NoError(t, err)
+		return
+	}
+	t.Error("no position with both safe and self eliminating placements found")
+}
+
+func Test_TsuroPartners(t *testing.T) {
+	teams := []string{TeamA, TeamB, "TeamC", "TeamD"}
+	alliances := [][]string{{TeamA, "TeamC"}, {TeamB, "TeamD"}}
+	tests := []struct {
+		name      string
+		variant   string
+		alliances [][]string
+	}{
+		{name: "alliances outside partners variant", variant: VariantClassic, alliances: alliances},
+		{name: "missing alliances", variant: VariantPartners},
+		{name: "single alliance", variant: VariantPartners, alliances: [][]string{teams}},
+		{name: "team in two alliances", variant: VariantPartners, alliances: [][]string{{TeamA, "TeamC"}, {TeamA, TeamB, "TeamD"}}},
+		{name: "team without alliance", variant: VariantPartners, alliances: [][]string{{TeamA, "TeamC"}, {TeamB}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewTsuro(&bg.BoardGameOptions{
+				Teams:       teams,
+				MoreOptions: TsuroMoreOptions{Variant: test.variant, Alliances: test.alliances},
+			})
+			assert.Error(t, err)
+		})
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		tsuro := newTestTsuro(t, teams, TsuroMoreOptions{
+			Seed:         seed,
+			Variant:      VariantPartners,
+			RandomTokens: true,
+			Alliances:    alliances,
+		})
+		assert.Same(t, tsuro.state.hands[TeamA], tsuro.state.hands["TeamC"])
+		assert.Same(t, tsuro.state.hands[TeamB], tsuro.state.hands["TeamD"])
+		assert.NotSame(t, tsuro.state.hands[TeamA], tsuro.state.hands[TeamB])
+		snapshot, _ := tsuro.GetSnapshot(TeamA)
+		assert.Len(t, snapshot.MoreData.(TsuroSnapshotData).Hands, 2)
+
+		random := rand.New(rand.NewSource(seed))
+		for len(tsuro.state.winners) == 0 {
+			placements, _ := tsuro.Placements(tsuro.state.turn)
+			placement := placements[random.Intn(len(placements))]
+			if err := tsuro.Do(&bg.BoardGameAction{
+				Team:       tsuro.state.turn,
+				ActionType: ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{
+					Row:    placement.Row,
+					Column: placement.Column,
+					Tile:   placement.Tile,
+				},
+			}); err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+		}
+		// winners are always whole alliances
+		for _, alliance := range alliances {
+			assert.Equal(t, contains(tsuro.state.winners, alliance[0]), contains(tsuro.state.winners, alliance[1]))
+		}
+
+		builder := Builder{}
+		loaded, err := builder.Load(tsuro.GetBGN())
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		assert.Equal(t, tsuro.state.winners, loaded.(*Tsuro).state.winners)
+	}
+}
+
+func Test_TsuroPath(t *testing.T) {
+	adjacent := map[string]string{"A": "F", "B": "E", "C": "H", "D": "G", "E": "B", "F": "A", "G": "D", "H": "C"}
+	for seed := int64(0); seed < 10; seed++ {
+		tsuro := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, TsuroMoreOptions{Seed: seed, RandomTokens: true})
+		start := make(map[string]token)
+		for team, token := range tsuro.state.tokens {
+			start[team] = *token
+		}
+		random := rand.New(rand.NewSource(seed))
+		for len(tsuro.state.winners) == 0 {
+			placements, _ := tsuro.Placements(tsuro.state.turn)
+			placement := placements[random.Intn(len(placements))]
+			assert.NoError(t, tsuro.Do(&bg.BoardGameAction{
+				Team:        tsuro.state.turn,
+				ActionType:  ActionPlaceTile,
+				MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+			}))
+		}
+		for _, team := range tsuro.state.teams {
+			path, err := tsuro.Path(team)
+			assert.NoError(t, err)
+			if len(path) == 0 {
+				continue
+			}
+			// the route starts on the starting notch and ends where the token is
+			assert.Equal(t, start[team].Row, path[0].Row)
+			assert.Equal(t, start[team].Col, path[0].Column)
+			assert.Equal(t, start[team].Notch, path[0].Entry)
+			last := path[len(path)-1]
+			assert.Equal(t, tsuro.state.tokens[team].Row, last.Row)
+			assert.Equal(t, tsuro.state.tokens[team].Col, last.Column)
+			assert.Equal(t, tsuro.state.tokens[team].Notch, last.Exit)
+			// each segment follows the tile it is on and enters where the previous one left
+			for idx, segment := range path {
+				tile := tsuro.state.board.board[segment.Row][segment.Column]
+				assert.Equal(t, segment.Exit, tile.GetDestination(segment.Entry))
+				if idx > 0 {
+					assert.Equal(t, adjacent[path[idx-1].Exit], segment.Entry)
+					next, err := newToken(path[idx-1].Row, path[idx-1].Column, path[idx-1].Exit).getAdjacent(6, 6)
+					assert.NoError(t, err)
+					assert.Equal(t, newToken(segment.Row, segment.Column, segment.Entry), next)
+				}
+			}
+		}
+	}
+	_, err := (&Tsuro{state: &state{teams: []string{TeamA}}}).Path(TeamB)
+	assert.Error(t, err)
+}
+
+func Test_TsuroResign(t *testing.T) {
+	teams := []string{TeamA, TeamB, "TeamC"}
+	tests := []struct {
+		name    string
+		teams   []string
+		resign  []string
+		setup   func(s *state)
+		turn    string
+		winners []string
+		dragon  string
+		deck    int
+		err     bool
+	}{
+		{name: "resign on own turn", teams: teams, resign: []string{TeamA}, turn: TeamB, deck: 35 - 9 + 3},
+		{name: "resign on another turn", teams: teams, resign: []string{TeamB}, turn: TeamA, deck: 35 - 9 + 3},
+		{name: "last team standing wins", teams: teams[:2], resign: []string{TeamB}, turn: TeamA, winners: []string{TeamA}, deck: 35 - 6 + 3},
+		{name: "resign twice", teams: teams, resign: []string{TeamB, TeamB}, err: true},
+		{name: "unknown team", teams: teams, resign: []string{"TeamD"}, err: true},
+		{
+			name:   "dragon holder draws returned tiles",
+			teams:  teams,
+			resign: []string{TeamA},
+			setup: func(s *state) {
+				s.deck.deck = nil
+				s.dragon = TeamA
+				s.hands[TeamB].hand = s.hands[TeamB].hand[:1]
+			},
+			turn:   TeamB,
+			dragon: "",
+			deck:   2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tsuro := newTestTsuro(t, test.teams, TsuroMoreOptions{RandomTokens: true})
+			if test.setup != nil {
+				test.setup(tsuro.state)
+			}
+			var err error
+			for _, team := range test.resign {
+				err = tsuro.Do(&bg.BoardGameAction{Team: team, ActionType: ActionResign})
+			}
+			assert.Equal(t, test.err, err != nil)
+			if test.err {
+				return
+			}
+			assert.Equal(t, test.turn, tsuro.state.turn)
+			assert.Equal(t, test.winners, append([]string(nil), tsuro.state.winners...))
+			assert.Equal(t, test.dragon, tsuro.state.dragon)
+			assert.Len(t, tsuro.state.deck.deck, test.deck)
+			for _, team := range test.resign {
+				assert.False(t, tsuro.state.alive[team])
+				assert.Empty(t, tsuro.state.hands[team].hand)
+			}
+			if test.setup == nil {
+				loaded, err := (&Builder{}).Load(tsuro.GetBGN())
+				if err != nil {
+					t.Error(err)
+					t.FailNow()
+				}
+				assert.Equal(t, tsuro.state.alive, loaded.(*Tsuro).state.alive)
+				assert.Equal(t, tsuro.state.turn, loaded.(*Tsuro).state.turn)
+			}
+		})
+	}
+}
+
+func Test_TsuroRotateDuplicateTiles(t *testing.T) {
+	copies := make([]string, 0)
+	for i := 0; i < 8; i++ {
+		copies = append(copies, "AHBGCDEF")
+	}
+	game := newTestTsuro(t, []string{TeamA, TeamB}, TsuroMoreOptions{Seed: 9, RandomTokens: true, Tiles: copies})
+	second := 1
+	assert.NoError(t, game.Do(&bg.BoardGameAction{
+		Team:        TeamA,
+		ActionType:  ActionRotateTileRight,
+		MoreDetails: RotateTileActionDetails{Tile: "AHBGCDEF", Index: &second},
+	}))
+	hand := game.state.hands[TeamA].hand
+	assert.Equal(t, "AHBGCDEF", hand[0].Edges)
+	assert.NotEqual(t, "AHBGCDEF", hand[1].Edges)
+	assert.Equal(t, "AHBGCDEF", hand[2].Edges)
+
+	// the index is kept in the record so the same copy is rotated when it is replayed
+	for _, record := range []*bgn.Game{game.GetBGN(), game.GetReadableBGN()} {
+		loaded, err := (&Builder{}).Load(record)
+		assert.NoError(t, err)
+		expected, _ := game.GetSnapshot()
+		actual, _ := loaded.GetSnapshot()
+		assert.Equal(t, expected, actual)
+	}
+
+	outside := 3
+	assert.Error(t, game.Do(&bg.BoardGameAction{
+		Team:        TeamA,
+		ActionType:  ActionRotateTileLeft,
+		MoreDetails: RotateTileActionDetails{Tile: "AHBGCDEF", Index: &outside},
+	}))
+}
+
+func Benchmark_TsuroRandomGame(b *testing.B) {
+	tests := []struct {
+		name    string
+		options TsuroMoreOptions
+	}{
+		{name: "classic", options: TsuroMoreOptions{Variant: VariantClassic}},
+		{name: "most crossings", options: TsuroMoreOptions{Variant: VariantMostCrossings}},
+		{name: "seas", options: TsuroMoreOptions{Variant: VariantSeas}},
+	}
+	teams := []string{TeamA, TeamB, "TeamC", "TeamD"}
+	for _, test := range tests {
+		b.Run(test.name, func(b *testing.B) {
+			random := rand.New(rand.NewSource(0))
+			for i := 0; i < b.N; i++ {
+				options := test.options
+				options.Seed = int64(i)
+				options.RandomTokens = true
+				game := newTestTsuro(b, teams, options)
+				for len(game.state.winners) == 0 {
+					targets, _ := game.Placements(game.state.turn)
+					if len(targets) == 0 {
+						b.Fatalf("%s has no placements", game.state.turn)
+					}
+					target := targets[random.Intn(len(targets))]
+					err := game.Do(&bg.BoardGameAction{
+						Team:        game.state.turn,
+						ActionType:  ActionPlaceTile,
+						MoreDetails: PlaceTileActionDetails{Row: target.Row, Column: target.Column, Tile: target.Tile},
+					})
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// maxFuzzActions bounds a fuzzed game as every game ends long before the tiles and rotations run out
+const maxFuzzActions = 2000
+
+func FuzzTsuro(f *testing.F) {
+	for idx := range variants {
+		for teams := 0; teams <= maxTeams-minTeams; teams++ {
+			f.Add(int64(idx*teams), uint8(idx), uint8(teams), uint8(idx+teams), uint8(teams), uint8(idx))
+		}
+	}
+	f.Fuzz(func(t *testing.T, seed int64, variant, teams, flags, size, tileCount uint8) {
+		names := make([]string, minTeams+int(teams)%(maxTeams-minTeams+1))
+		alliances := make([][]string, 2)
+		for idx := range names {
+			names[idx] = "Team" + string(rune('A'+idx))
+			alliances[idx%2] = append(alliances[idx%2], names[idx])
+		}
+		random := rand.New(rand.NewSource(seed))
+		options := TsuroMoreOptions{
+			Seed:         seed,
+			Variant:      variants[int(variant)%len(variants)],
+			RandomTokens: flags&1 != 0,
+			NoSuicide:    flags&2 != 0,
+			SecretSeed:   flags&4 != 0,
+		}
+		if options.Variant == VariantPartners {
+			options.Alliances = alliances
+		}
+		if options.Variant != VariantSeas && size%2 != 0 {
+			options.BoardSize = minBoardSize + int(size/2)%(maxBoardSize-minBoardSize+1)
+		}
+		if flags&8 != 0 {
+			// a custom deck with enough tiles to deal every hand which may hold several copies of a tile
+			options.Tiles = make([]string, 3*len(names)+int(tileCount)%len(tiles))
+			for idx := range options.Tiles {
+				options.Tiles[idx] = tiles[random.Intn(len(tiles))]
+			}
+		}
+		game := newTestTsuro(t, names, options)
+		for actions := 0; len(game.state.winners) == 0; actions++ {
+			if actions >= maxFuzzActions {
+				t.Fatalf("game did not end within %d actions", maxFuzzActions)
+			}
+			action := randomAction(t, game, random)
+			if err := game.Do(action); err != nil {
+				t.Fatalf("%s %s %v: %s", action.Team, action.ActionType, action.MoreDetails, err)
+			}
+			checkInvariants(t, game)
+			if actions%25 == 0 {
+				checkBGN(t, game)
+			}
+		}
+		checkBGN(t, game)
+	})
+}
+
+// randomAction picks a random legal action for the team on turn occasionally rotating a tile or resigning
+func randomAction(t *testing.T, game *Tsuro, random *rand.Rand) *bg.BoardGameAction {
+	team := game.state.turn
+	if game.state.placingTokens() {
+		tokens := game.state.startingTokens()
+		if len(tokens) == 0 {
+			t.Fatalf("%s has nowhere to place their token", team)
+		}
+		token := tokens[random.Intn(len(tokens))]
+		return &bg.BoardGameAction{
+			Team:        team,
+			ActionType:  ActionPlaceToken,
+			MoreDetails: PlaceTokenActionDetails{Row: token.Row, Column: token.Col, Notch: token.Notch},
+		}
+	}
+	hand := game.state.hands[team].hand
+	switch r := random.Intn(100); {
+	case r == 0:
+		return &bg.BoardGameAction{Team: team, ActionType: ActionResign}
+	case r < 20 && len(hand) > 0:
+		actionType := ActionRotateTileRight
+		if r%2 == 0 {
+			actionType = ActionRotateTileLeft
+		}
+		return &bg.BoardGameAction{
+			Team:        team,
+			ActionType:  actionType,
+			MoreDetails: RotateTileActionDetails{Tile: hand[random.Intn(len(hand))].Edges},
+		}
+	}
+	placements, err := game.Placements(team)
+	if err != nil || len(placements) == 0 {
+		t.Fatalf("%s has no placements: %v", team, err)
+	}
+	placement := placements[random.Intn(len(placements))]
+	return &bg.BoardGameAction{
+		Team:        team,
+		ActionType:  ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+	}
+}
+
+// checkInvariants fails the test if the game breaks a rule that holds after every action
+func checkInvariants(t *testing.T, game *Tsuro) {
+	s := game.state
+	found := make(map[int]int)
+	count := func(tiles []*tile) {
+		for _, tile := range tiles {
+			if tile != nil {
+				found[tile.shape.id]++
+			}
+		}
+	}
+	for _, row := range s.board.board {
+		count(row)
+	}
+	count(s.deck.deck)
+	count(s.destroyed) // tiles destroyed by daikaiju leave the game
+	counted := make(map[*hand]bool)
+	for _, team := range s.teams {
+		if h := s.hands[team]; !counted[h] {
+			counted[h] = true
+			count(h.hand)
+		}
+	}
+	expected := make(map[int]int)
+	for _, edges := range deckTiles(game.options) {
+		tile, _ := newTile(edges)
+		expected[tile.shape.id]++
+	}
+	for id, n := range found {
+		if n != expected[id] {
+			t.Fatalf("tile %d found %d times instead of %d", id, n, expected[id])
+		}
+	}
+	for id, n := range expected {
+		if found[id] != n {
+			t.Fatalf("tile %d found %d times instead of %d", id, found[id], n)
+		}
+	}
+	if len(s.winners) == 0 && !s.alive[s.turn] {
+		t.Fatalf("turn belongs to %s who is out", s.turn)
+	}
+}
+
+// checkBGN fails the test if loading the game's BGN does not reproduce the game
+func checkBGN(t *testing.T, game *Tsuro) {
+	builder := Builder{}
+	loaded, err := builder.Load(game.GetBGN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	view := View{Mode: ViewOmniscient}
+	if game.options.SecretSeed && len(game.state.winners) == 0 {
+		// hands and the deck stay hidden in the record of a live game with a secret seed
+		view = View{Mode: ViewSpectator}
+	}
+	expected, _ := game.GetView(view)
+	actual, _ := loaded.(*Tsuro).GetView(view)
+	if !assert.Equal(t, expected, actual) {
+		t.FailNow()
+	}
+}
+
+// midgame returns a four team game after each team has placed a few random tiles that knock nobody out
+func midgame(b *testing.B) *Tsuro {
+	game := newTestTsuro(b, []string{TeamA, TeamB, "TeamC", "TeamD"}, TsuroMoreOptions{Seed: 123, RandomTokens: true})
+	random := rand.New(rand.NewSource(0))
+	for i := 0; i < 8; i++ {
+		targets, _ := game.Placements(game.state.turn)
+		placements := make([]*PlacementTarget, 0)
+		for _, target := range targets {
+			if len(target.Eliminated) == 0 {
+				placements = append(placements, target)
+			}
+		}
+		if len(placements) == 0 {
+			b.Fatalf("%s has no safe placements", game.state.turn)
+		}
+		placement := placements[random.Intn(len(placements))]
+		if err := game.Do(&bg.BoardGameAction{
+			Team:        game.state.turn,
+			ActionType:  ActionPlaceTile,
+			MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return game
+}
+
+func Benchmark_TsuroPlaceTile(b *testing.B) {
+	game := midgame(b)
+	placements, _ := game.Placements(game.state.turn)
+	placement := placements[0]
+	action := &bg.BoardGameAction{
+		Team:        game.state.turn,
+		ActionType:  ActionPlaceTile,
+		MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		clone := game.Clone()
+		b.StartTimer()
+		if err := clone.Do(action); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_TsuroGetSnapshot(b *testing.B) {
+	game := midgame(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := game.GetSnapshot(TeamA); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -9,15 +9,6 @@ func contains(list []string, item string) bool {
 	return false
 }
 
-func mapContainsVal(m map[string]string, item string) bool {
-	for _, val := range m {
-		if val == item {
-			return true
-		}
-	}
-	return false
-}
-
 func indexOf(items []string, item string) int {
 	for index, it := range items {
 		if it == item {
@@ -35,10 +26,12 @@ func reverseMap(m map[string]string) map[string]string {
 	return n
 }
 
-func max(m map[string]int) []string {
+// max lists the keys with the highest value in the order of keys
+func max(keys []string, m map[string]int) []string {
 	currMax := 0
 	currKeys := []string{}
-	for k, v := range m {
+	for _, k := range keys {
+		v := m[k]
 		if v > currMax {
 			currMax = v
 			currKeys = []string{k}
@@ -59,3 +52,11 @@ func duplicates(list []string) bool {
 	}
 	return false
 }
+
+func flatten(lists [][]string) []string {
+	flat := make([]string, 0)
+	for _, list := range lists {
+		flat = append(flat, list...)
+	}
+	return flat
+}
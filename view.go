@@ -0,0 +1,144 @@
+package go_tsuro
+
+import (
+	"fmt"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/quibbble/go-boardgame/pkg/bgerr"
+)
+
+// GetView returns a snapshot exposing only what the view allows
+func (t *Tsuro) GetView(view View) (*bg.BoardGameSnapshot, error) {
+	switch view.Mode {
+	case ViewPlayer:
+		if !contains(t.state.teams, view.Team) {
+			return nil, &bgerr.Error{
+				Err:    fmt.Errorf("%s not a valid team", view.Team),
+				Status: bgerr.StatusUnknownTeam,
+			}
+		}
+	case ViewSpectator, ViewOmniscient:
+	case ViewReplay:
+		if view.Delay < 0 {
+			return nil, &bgerr.Error{
+				Err:    fmt.Errorf("delay cannot be negative"),
+				Status: bgerr.StatusInvalidOption,
+			}
+		}
+		cut, _ := t.cut(view.Delay)
+		game, err := t.replay(t.actions[:cut])
+		if err != nil {
+			return nil, err
+		}
+		// show the clocks as they were recorded rather than as the replay ran
+		if timer := t.timerAt(cut); timer != nil {
+			game.timer = timer
+		} else {
+			game.options.TurnTime, game.options.GameTime = 0, 0
+		}
+		return game.view(ViewSpectator, ""), nil
+	default:
+		return nil, &bgerr.Error{
+			Err:    fmt.Errorf("invalid view mode %s, must be one of %v", view.Mode, viewModes),
+			Status: bgerr.StatusInvalidOption,
+		}
+	}
+	return t.view(view.Mode, view.Team), nil
+}
+
+// view builds the snapshot seen in mode by team which is only used in the player mode
+func (t *Tsuro) view(mode, team string) *bg.BoardGameSnapshot {
+	sees := func(other string) bool {
+		return t.sees(mode, team, other)
+	}
+	hands := make(map[string][]*tile)
+	handSizes := make(map[string]int)
+	for t2, hand := range t.state.hands {
+		if sees(t2) {
+			hands[t2] = hand.hand
+		}
+		handSizes[t2] = len(hand.hand)
+	}
+	var deck []*tile
+	if mode == ViewOmniscient {
+		deck = t.state.deck.deck
+	}
+	alive := make([]string, 0)
+	for _, team := range t.state.teams {
+		if t.state.alive[team] {
+			alive = append(alive, team)
+		}
+	}
+	events := make([]*Event, 0, len(t.state.events))
+	for _, event := range t.state.events {
+		if event.Type == EventTileDrawn && !sees(event.Team) {
+			// only teams holding the hand see what was drawn into it
+			hidden := *event
+			hidden.Tile = ""
+			event = &hidden
+		}
+		events = append(events, event)
+	}
+	actions := make([]*bg.BoardGameAction, 0, len(t.actions))
+	for _, action := range t.actions {
+		if (action.ActionType == ActionRotateTileRight || action.ActionType == ActionRotateTileLeft) && !sees(action.Team) {
+			// rotations show tiles in hand
+			continue
+		}
+		actions = append(actions, action)
+	}
+	var points map[string]int
+	if t.state.variant == VariantLongestPath || t.state.variant == VariantMostCrossings {
+		points = t.state.points
+	}
+	details := TsuroSnapshotData{
+		Board:          t.state.board.board,
+		TilesRemaining: len(t.state.deck.deck),
+		Hands:          hands,
+		HandSizes:      handSizes,
+		Deck:           deck,
+		Tokens:         t.state.tokens,
+		Alive:          alive,
+		Paths:          t.state.paths,
+		Alliances:      t.state.alliances,
+		Dragon:         t.state.dragon,
+		Daikaiju:       t.state.daikaiju,
+		Variant:        t.state.variant,
+		Points:         points,
+		Events:         events,
+	}
+	details.TurnTimeLeft, details.GameTimeLeft = t.timeLeft()
+	var targets []*bg.BoardGameAction
+	if len(t.state.winners) == 0 {
+		switch mode {
+		case ViewOmniscient:
+			targets = t.state.targets()
+		case ViewPlayer:
+			targets = t.state.targets(team)
+		}
+		if sees(t.state.turn) {
+			details.Placements = t.state.legalPlacements(t.state.turn)
+		}
+	}
+	return &bg.BoardGameSnapshot{
+		Turn:     t.state.turn,
+		Teams:    t.state.teams,
+		Winners:  t.state.winners,
+		MoreData: details,
+		Targets:  targets,
+		Actions:  actions,
+		Message:  t.state.message(),
+	}
+}
+
+// sees is true if the view in mode by team shows the tiles in other's hand
+func (t *Tsuro) sees(mode, team, other string) bool {
+	switch mode {
+	case ViewOmniscient:
+		return true
+	case ViewPlayer:
+		// partners and open tiles share a hand
+		return t.state.hands[team] == t.state.hands[other]
+	}
+	return false
+}
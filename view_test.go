@@ -0,0 +1,99 @@
+package go_tsuro
+
+import (
+	"math/rand"
+	"testing"
+
+	bg "github.com/quibbble/go-boardgame"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TsuroViews(t *testing.T) {
+	tsuro := newTestTsuro(t, []string{TeamA, TeamB, "TeamC"}, TsuroMoreOptions{Seed: 3, RandomTokens: true})
+	random := rand.New(rand.NewSource(3))
+	for i := 0; i < 3; i++ {
+		placements, _ := tsuro.Placements(tsuro.state.turn)
+		placement := placements[random.Intn(len(placements))]
+		assert.NoError(t, tsuro.Do(&bg.BoardGameAction{
+			Team:        tsuro.state.turn,
+			ActionType:  ActionPlaceTile,
+			MoreDetails: PlaceTileActionDetails{Row: placement.Row, Column: placement.Column, Tile: placement.Tile},
+		}))
+	}
+	if len(tsuro.state.winners) > 0 {
+		t.Fatal("game should not be over")
+	}
+	rotating := tsuro.state.getNextTurn(tsuro.state.turn)
+	assert.NoError(t, tsuro.Do(&bg.BoardGameAction{
+		Team:        rotating,
+		ActionType:  ActionRotateTileRight,
+		MoreDetails: RotateTileActionDetails{Tile: tsuro.state.hands[rotating].hand[0].Edges},
+	}))
+
+	tests := []struct {
+		name       string
+		view       View
+		hands      []string
+		deck       bool
+		rotations  bool
+		targets    bool
+		placements bool
+		actions    int
+		err        bool
+	}{
+		{name: "omniscient", view: View{Mode: ViewOmniscient}, hands: tsuro.state.teams, deck: true, rotations: true, targets: true, placements: true, actions: 4},
+		{name: "turn player", view: View{Mode: ViewPlayer, Team: tsuro.state.turn}, hands: []string{tsuro.state.turn}, targets: true, placements: true, actions: 3},
+		{name: "rotating player", view: View{Mode: ViewPlayer, Team: rotating}, hands: []string{rotating}, rotations: true, targets: true, actions: 4},
+		{name: "spectator", view: View{Mode: ViewSpectator}, hands: []string{}, actions: 3},
+		{name: "replay", view: View{Mode: ViewReplay, Delay: 2}, hands: []string{}, actions: 2},
+		{name: "unknown team", view: View{Mode: ViewPlayer, Team: "TeamD"}, err: true},
+		{name: "negative delay", view: View{Mode: ViewReplay, Delay: -1}, err: true},
+		{name: "invalid mode", view: View{Mode: "Admin"}, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot, err := tsuro.GetView(test.view)
+			assert.Equal(t, test.err, err != nil)
+			if test.err {
+				return
+			}
+			data := snapshot.MoreData.(TsuroSnapshotData)
+			hands := make([]string, 0)
+			for team := range data.Hands {
+				hands = append(hands, team)
+			}
+			assert.ElementsMatch(t, test.hands, hands)
+			assert.Len(t, data.HandSizes, 3)
+			assert.Equal(t, test.deck, data.Deck != nil)
+			if test.deck {
+				assert.Len(t, data.Deck, data.TilesRemaining)
+			}
+			rotations := false
+			for _, action := range snapshot.Actions {
+				rotations = rotations || action.ActionType == ActionRotateTileRight
+			}
+			assert.Equal(t, test.rotations, rotations)
+			assert.Len(t, snapshot.Actions, test.actions)
+			assert.Equal(t, test.targets, len(snapshot.Targets.([]*bg.BoardGameAction)) > 0)
+			assert.Equal(t, test.placements, len(data.Placements) > 0)
+		})
+	}
+
+	// a replay shows the board as it was
+	replay, _ := tsuro.GetView(View{Mode: ViewReplay, Delay: 2})
+	tiles := 0
+	for _, row := range replay.MoreData.(TsuroSnapshotData).Board {
+		for _, tile := range row {
+			if tile != nil {
+				tiles++
+			}
+		}
+	}
+	assert.Equal(t, 2, tiles)
+
+	// with no team the snapshot is the omniscient view showing every hand
+	expected, _ := tsuro.GetView(View{Mode: ViewOmniscient})
+	actual, _ := tsuro.GetSnapshot()
+	assert.Equal(t, expected, actual)
+	assert.Len(t, actual.MoreData.(TsuroSnapshotData).Hands, len(tsuro.state.hands))
+}